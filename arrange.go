@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"math"
+)
+
+// isRegularWindow reports whether the root container is a window created by Window,
+// not a popup, a modal, a tooltip or a window declared in another window like a dropdown list.
+func (c *container) isRegularWindow() bool {
+	return c.owner == nil && (c.opt&(optionPopup|optionModal|optionTooltip)) == 0
+}
+
+// arrangeableWindows returns the open regular windows that are not docked in the z-order.
+func (c *Context) arrangeableWindows() []*container {
+	var cnts []*container
+	for _, cnt := range c.rootContainers {
+		if !cnt.open || !cnt.isRegularWindow() || cnt.docked {
+			continue
+		}
+		cnts = append(cnts, cnt)
+	}
+	return cnts
+}
+
+// snapWindowBounds returns the bounds snapped to the screen edges and the edges of the other windows.
+func (c *Context) snapWindowBounds(cnt *container, bounds image.Rectangle) image.Rectangle {
+	threshold := c.style().spacing * 2
+
+	xs := []int{}
+	ys := []int{}
+	if screen := c.screenBounds(); !screen.Empty() {
+		xs = append(xs, screen.Min.X, screen.Max.X)
+		ys = append(ys, screen.Min.Y, screen.Max.Y)
+	}
+	for _, other := range c.rootContainers {
+		if other == cnt || !other.open || !other.isRegularWindow() {
+			continue
+		}
+		b := other.layout.Bounds
+		if other.collapsed {
+			b.Max.Y = b.Min.Y + c.style().titleHeight
+		}
+		if b.Empty() {
+			continue
+		}
+		// Snap only to the windows close to the bounds.
+		if !b.Inset(-threshold).Overlaps(bounds) {
+			continue
+		}
+		xs = append(xs, b.Min.X, b.Max.X)
+		ys = append(ys, b.Min.Y, b.Max.Y)
+	}
+
+	snap := func(lo, hi int, edges []int) int {
+		d := threshold + 1
+		for _, e := range edges {
+			for _, v := range []int{lo, hi} {
+				if abs(e-v) < abs(d) {
+					d = e - v
+				}
+			}
+		}
+		if abs(d) > threshold {
+			return 0
+		}
+		return d
+	}
+	return bounds.Add(image.Pt(snap(bounds.Min.X, bounds.Max.X, xs), snap(bounds.Min.Y, bounds.Max.Y, ys)))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// TileWindows arranges all the open windows in a grid that fills the screen.
+//
+// Docked windows are not arranged.
+func (c *Context) TileWindows() {
+	screen := c.screenBounds()
+	cnts := c.arrangeableWindows()
+	if screen.Empty() || len(cnts) == 0 {
+		return
+	}
+
+	spacing := c.style().spacing
+	cols := int(math.Ceil(math.Sqrt(float64(len(cnts)))))
+	rows := (len(cnts) + cols - 1) / cols
+	area := screen.Inset(spacing)
+	for i, cnt := range cnts {
+		col, row := i%cols, i/cols
+		x0 := area.Min.X + (area.Dx()+spacing)*col/cols
+		x1 := area.Min.X + (area.Dx()+spacing)*(col+1)/cols - spacing
+		y0 := area.Min.Y + (area.Dy()+spacing)*row/rows
+		y1 := area.Min.Y + (area.Dy()+spacing)*(row+1)/rows - spacing
+		cnt.layout.Bounds = image.Rect(x0, y0, x1, y1)
+	}
+}
+
+// CascadeWindows arranges all the open windows diagonally so that all the title bars are visible.
+//
+// The sizes of the windows are kept unless the windows are larger than the screen.
+// Docked windows are not arranged.
+func (c *Context) CascadeWindows() {
+	screen := c.screenBounds()
+	cnts := c.arrangeableWindows()
+	if screen.Empty() || len(cnts) == 0 {
+		return
+	}
+
+	spacing := c.style().spacing
+	step := c.style().titleHeight
+	area := screen.Inset(spacing)
+	pos := area.Min
+	for _, cnt := range cnts {
+		size := cnt.layout.Bounds.Size()
+		size.X = min(size.X, area.Dx())
+		size.Y = min(size.Y, area.Dy())
+		if pos.X+size.X > area.Max.X || pos.Y+size.Y > area.Max.Y {
+			pos = area.Min
+		}
+		cnt.layout.Bounds = image.Rectangle{Min: pos, Max: pos.Add(size)}
+		pos = pos.Add(image.Pt(step, step))
+	}
+}
+
+// ResetWindows moves all the open windows back to their initial bounds.
+//
+// ResetWindows is useful when windows are out of the screen, e.g. after the scale is changed.
+// Docked windows are not moved.
+func (c *Context) ResetWindows() {
+	for _, cnt := range c.arrangeableWindows() {
+		cnt.layout.Bounds = cnt.initialBounds
+	}
+}
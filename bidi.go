@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// bidiRun is a run of text in a single direction.
+type bidiRun struct {
+	// start and end are the byte offsets of the run in the text.
+	start int
+	end   int
+
+	rtl bool
+}
+
+// hasRTL reports whether the text has a right-to-left character.
+func hasRTL(str string) bool {
+	for _, r := range str {
+		if r < 0x0590 {
+			continue
+		}
+		switch p, _ := bidi.LookupRune(r); p.Class() {
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// isRTLParagraph reports whether the paragraph direction of the text is right-to-left.
+// The paragraph direction is determined by the first strong character.
+func isRTLParagraph(str string) bool {
+	for _, r := range str {
+		switch p, _ := bidi.LookupRune(r); p.Class() {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// visualRuns returns the directional runs of a line in the visual order from left to right.
+//
+// The runs are reordered by the rule L2 of the Unicode Bidirectional Algorithm with the resolved embedding levels.
+// Explicit embeddings and isolates are not considered, so the levels are at most 2.
+func visualRuns(str string) []bidiRun {
+	if !hasRTL(str) {
+		return []bidiRun{{start: 0, end: len(str)}}
+	}
+
+	rtlParagraph := isRTLParagraph(str)
+	var p bidi.Paragraph
+	var opts []bidi.Option
+	if rtlParagraph {
+		opts = append(opts, bidi.DefaultDirection(bidi.RightToLeft))
+	}
+	if _, err := p.SetString(str, opts...); err != nil {
+		return []bidiRun{{start: 0, end: len(str), rtl: rtlParagraph}}
+	}
+	o, err := p.Order()
+	if err != nil {
+		return []bidiRun{{start: 0, end: len(str), rtl: rtlParagraph}}
+	}
+
+	// The positions of the runs are in runes. Convert them to bytes.
+	byteOffsets := make([]int, 0, len(str)+1)
+	for i := range str {
+		byteOffsets = append(byteOffsets, i)
+	}
+	byteOffsets = append(byteOffsets, len(str))
+
+	// The runs of the bidi package have only the directions. Resolve the levels of the runs.
+	// A right-to-left run is at the level 1.
+	// A left-to-right run is at the level 2 in a right-to-left paragraph.
+	// In a left-to-right paragraph, the numbers in a left-to-right run can be at the level 2 (the rule I1).
+	var numbers []bool
+	if !rtlParagraph {
+		numbers = numberRunes(str)
+	}
+	var runs []bidiRun
+	var levels []int
+	for i := range o.NumRuns() {
+		r := o.Run(i)
+		start, end := r.Pos()
+		if r.Direction() == bidi.RightToLeft {
+			runs = append(runs, bidiRun{start: byteOffsets[start], end: byteOffsets[end+1], rtl: true})
+			levels = append(levels, 1)
+			continue
+		}
+		if rtlParagraph {
+			runs = append(runs, bidiRun{start: byteOffsets[start], end: byteOffsets[end+1]})
+			levels = append(levels, 2)
+			continue
+		}
+		for j := start; j <= end; {
+			k := j + 1
+			for k <= end && numbers[k] == numbers[j] {
+				k++
+			}
+			runs = append(runs, bidiRun{start: byteOffsets[j], end: byteOffsets[k]})
+			levels = append(levels, 2*boolToInt(numbers[j]))
+			j = k
+		}
+	}
+
+	// Reverse any maximal sequence of runs at the level k or higher, from the highest level to the lowest odd level (the rule L2).
+	maxLevel := slices.Max(levels)
+	for k := maxLevel; k >= 1; k-- {
+		for i := 0; i < len(runs); {
+			if levels[i] < k {
+				i++
+				continue
+			}
+			j := i
+			for j < len(runs) && levels[j] >= k {
+				j++
+			}
+			slices.Reverse(runs[i:j])
+			slices.Reverse(levels[i:j])
+			i = j
+		}
+	}
+	return runs
+}
+
+// numberRunes reports whether each rune of the text in a left-to-right paragraph is resolved as a number,
+// which is at the level 2 in a left-to-right run.
+//
+// A European number after a left-to-right character is resolved as a left-to-right character (the rule W7).
+// A separator between numbers and a terminator adjacent to a number are resolved as numbers (the rules W4 and W5).
+func numberRunes(str string) []bool {
+	var classes []bidi.Class
+	for _, r := range str {
+		p, _ := bidi.LookupRune(r)
+		classes = append(classes, p.Class())
+	}
+
+	numbers := make([]bool, len(classes))
+	lastStrong := bidi.L
+	for i, cls := range classes {
+		switch cls {
+		case bidi.L, bidi.R, bidi.AL:
+			lastStrong = cls
+		case bidi.AN:
+			numbers[i] = true
+		case bidi.EN:
+			numbers[i] = lastStrong != bidi.L
+		}
+	}
+	for i, cls := range classes {
+		if cls == bidi.ES || cls == bidi.CS {
+			numbers[i] = i > 0 && i < len(classes)-1 && numbers[i-1] && numbers[i+1]
+		}
+	}
+	for i, cls := range classes {
+		if cls != bidi.ET || numbers[i] {
+			continue
+		}
+		// Find the end of the sequence of terminators.
+		j := i
+		for j < len(classes) && classes[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && numbers[i-1] && classes[i-1] == bidi.EN) || (j < len(classes) && numbers[j] && classes[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				numbers[k] = true
+			}
+		}
+	}
+	return numbers
+}
+
+// directionalFace returns the font face to render a run in the given direction.
+func directionalFace(face text.Face, rtl bool) text.Face {
+	f, ok := face.(*text.GoTextFace)
+	if !ok {
+		return face
+	}
+	d := text.DirectionLeftToRight
+	if rtl {
+		d = text.DirectionRightToLeft
+	}
+	if f.Direction == d {
+		return face
+	}
+	f2 := *f
+	f2.Direction = d
+	return &f2
+}
+
+// runString returns the string of the run to render.
+// A face that cannot shape text renders a right-to-left run by reversing the characters.
+func runString(str string, run bidiRun, face text.Face) string {
+	s := str[run.start:run.end]
+	if !run.rtl {
+		return s
+	}
+	if _, ok := face.(*text.GoTextFace); ok {
+		return s
+	}
+	return bidi.ReverseString(s)
+}
+
+// runWidth returns the width of the run in the text.
+func runWidth(str string, run bidiRun, face text.Face) float64 {
+	return text.Advance(runString(str, run, face), directionalFace(face, run.rtl))
+}
+
+// bidiTextWidth returns the width of the text that might have right-to-left characters.
+func bidiTextWidth(str string, face text.Face) int {
+	if !hasRTL(str) {
+		return int(text.Advance(str, face))
+	}
+	var w float64
+	for _, run := range visualRuns(str) {
+		w += runWidth(str, run, face)
+	}
+	return int(w)
+}
+
+// caretPosition returns the x position of the caret at the byte offset in the text,
+// relative to the left edge of the text.
+//
+// In a right-to-left run, the caret moves from right to left as the offset increases.
+func (c *Context) caretPosition(str string, offset int) int {
+	face := c.fontFace()
+	if !hasRTL(str) {
+		return int(text.Advance(str[:offset], face))
+	}
+	var x float64
+	for _, run := range visualRuns(str) {
+		w := runWidth(str, run, face)
+		if (run.start < offset && offset <= run.end) || (offset == 0 && run.start == 0) {
+			part := runWidth(str, bidiRun{start: run.start, end: offset, rtl: run.rtl}, face)
+			if run.rtl {
+				return int(x + w - part)
+			}
+			return int(x + part)
+		}
+		x += w
+	}
+	return int(x)
+}
+
+// caretStop is a position where the caret can be placed.
+type caretStop struct {
+	// offset is the byte offset in the text.
+	offset int
+
+	// x is the x position relative to the left edge of the text.
+	x int
+}
+
+// caretStops returns the caret stops of the text in the visual order from left to right.
+//
+// At a boundary of two runs, the last stop of the left run and the first stop of the right run are at the same position.
+func (c *Context) caretStops(str string) []caretStop {
+	face := c.fontFace()
+	var stops []caretStop
+	var x float64
+	for _, run := range visualRuns(str) {
+		w := runWidth(str, run, face)
+		first := len(stops)
+		for o := run.start; ; {
+			part := runWidth(str, bidiRun{start: run.start, end: o, rtl: run.rtl}, face)
+			if run.rtl {
+				stops = append(stops, caretStop{offset: o, x: int(x + w - part)})
+			} else {
+				stops = append(stops, caretStop{offset: o, x: int(x + part)})
+			}
+			if o == run.end {
+				break
+			}
+			_, size := utf8.DecodeRuneInString(str[o:])
+			o += size
+		}
+		if run.rtl {
+			slices.Reverse(stops[first:])
+		}
+		x += w
+	}
+	return stops
+}
+
+// moveCaret returns the byte offset of the caret moved by one character in the visual order.
+// dir is -1 to move the caret to the left, or 1 to move the caret to the right.
+//
+// In a right-to-left run, moving the caret to the right decreases the offset.
+// If the caret cannot be moved, moveCaret returns the offset as it is.
+func (c *Context) moveCaret(str string, offset int, dir int) int {
+	if !hasRTL(str) {
+		if dir < 0 && offset > 0 {
+			_, size := utf8.DecodeLastRuneInString(str[:offset])
+			return offset - size
+		}
+		if dir > 0 && offset < len(str) {
+			_, size := utf8.DecodeRuneInString(str[offset:])
+			return offset + size
+		}
+		return offset
+	}
+
+	// Step to the nearest caret stop in the direction.
+	stops := c.caretStops(str)
+	x := c.caretPosition(str, offset)
+	if dir > 0 {
+		for _, s := range stops {
+			if s.x > x {
+				return s.offset
+			}
+		}
+		return offset
+	}
+	for _, s := range slices.Backward(stops) {
+		if s.x < x {
+			return s.offset
+		}
+	}
+	return offset
+}
+
+// drawBidiText draws the text that might have right-to-left characters.
+// The position specified by options is the left edge of the text.
+func drawBidiText(dst *ebiten.Image, str string, face text.Face, options *text.DrawOptions) {
+	if !hasRTL(str) {
+		text.Draw(dst, str, face, options)
+		return
+	}
+	var x float64
+	for _, run := range visualRuns(str) {
+		f := directionalFace(face, run.rtl)
+		s := runString(str, run, face)
+		op := *options
+		op.GeoM.Reset()
+		op.GeoM.Translate(x, 0)
+		op.GeoM.Concat(options.GeoM)
+		if gf, ok := f.(*text.GoTextFace); ok && gf.Direction == text.DirectionRightToLeft {
+			// The origin of a right-to-left text is the right edge by default.
+			op.PrimaryAlign = text.AlignEnd
+		}
+		text.Draw(dst, s, f, &op)
+		x += runWidth(str, run, face)
+	}
+}
@@ -13,10 +13,10 @@ import "image"
 // A Button widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) Button(text string) EventHandler {
+func (c *Context) Button(text string) WidgetEventHandler {
 	pc := caller()
 	id := c.idStack.push(idPartFromCaller(pc))
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.button(text, optionAlignCenter, id)
 	})
 }
@@ -31,7 +31,46 @@ func (c *Context) button(text string, opt option, id widgetID) (EventHandler, er
 	}, func(bounds image.Rectangle) {
 		c.drawWidgetFrame(id, bounds, colorButton, opt)
 		if len(text) > 0 {
-			c.drawWidgetText(text, bounds, colorText, opt)
+			c.drawTruncatedWidgetText(text, bounds, colorText, opt)
+		}
+	})
+}
+
+// ToggleButton creates a toggle button widget with the given boolean state and text.
+//
+// The button stays pressed while *state is true.
+// When the button is clicked, *state is flipped.
+//
+// ToggleButton returns an EventHandler to handle value change events.
+// A returned EventHandler is never nil.
+//
+// A ToggleButton widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) ToggleButton(state *bool, text string) WidgetEventHandler {
+	pc := caller()
+	id := c.idStack.push(idPartFromCaller(pc))
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.toggleButton(state, text, optionAlignCenter, id)
+	})
+}
+
+func (c *Context) toggleButton(state *bool, text string, opt option, id widgetID) (EventHandler, error) {
+	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		var e EventHandler
+		if c.pointing.justPressed() && c.focus == id {
+			*state = !*state
+			e = &eventHandler{}
+		}
+		return e
+	}, func(bounds image.Rectangle) {
+		if *state {
+			c.drawFrame(bounds, colorButtonFocus)
+		} else {
+			c.drawWidgetFrame(id, bounds, colorButton, opt)
+		}
+		if len(text) > 0 {
+			c.drawTruncatedWidgetText(text, bounds, colorText, opt)
 		}
 	})
 }
@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// comboBoxMaxVisibleItems is the maximum number of items visible at once in a combo box list.
+const comboBoxMaxVisibleItems = 10
+
+type comboBoxState struct {
+	filter     string
+	lastFilter string
+
+	// items is the indices of the options matching the filter.
+	items []int
+
+	// itemsValid reports whether items is up to date.
+	itemsValid bool
+
+	// highlight is the index of the highlighted item in items.
+	highlight int
+
+	// scrollToHighlight reports whether the list should be scrolled to the highlighted item.
+	scrollToHighlight bool
+}
+
+// ComboBox creates a combo box widget that allows users to search and select from a list of options.
+//
+// ComboBox is similar to Dropdown, but the list has a text field to filter the options.
+// The options are matched with the filter text by prefix, substring, and subsequence in this priority.
+// The highlighted option can be changed with the up and down keys, and selected with the Enter key.
+// Only the visible options are rendered, so ComboBox can handle a large number of options.
+//
+// selectedIndex is a pointer to the currently selected option index (0-based).
+// options is a slice of strings representing the available choices.
+// Returns an EventHandler that triggers when the selection changes.
+func (c *Context) ComboBox(selectedIndex *int, options []string) WidgetEventHandler {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.comboBox(selectedIndex, options, idPart)
+	})
+}
+
+func (c *Context) comboBox(selectedIndex *int, options []string, idPart string) (EventHandler, error) {
+	if selectedIndex == nil || len(options) == 0 {
+		return &nullEventHandler{}, nil
+	}
+	if *selectedIndex < 0 || *selectedIndex >= len(options) {
+		*selectedIndex = 0
+	}
+	last := *selectedIndex
+
+	id := c.idStack.push(idPart)
+	filterID := id.push(idPartFromString("filter"))
+	listContainer := c.container(id, 0)
+	state := widgetState[comboBoxState](listContainer, id)
+
+	if listContainer.layout.Bounds.Empty() {
+		listContainer.open = false
+	}
+
+	if listContainer.open {
+		if !state.itemsValid || state.filter != state.lastFilter {
+			state.items = filterOptions(state.items[:0], options, state.filter)
+			state.itemsValid = true
+			state.lastFilter = state.filter
+			state.highlight = 0
+			state.scrollToHighlight = true
+		}
+
+		// Handle keyboard.
+		if len(state.items) > 0 {
+			if c.keyRepeated(ebiten.KeyUp) {
+				state.highlight--
+				state.scrollToHighlight = true
+			}
+			if c.keyRepeated(ebiten.KeyDown) {
+				state.highlight++
+				state.scrollToHighlight = true
+			}
+			state.highlight = clamp(state.highlight, 0, len(state.items)-1)
+			if c.isKeyJustPressed(ebiten.KeyEnter) {
+				*selectedIndex = state.items[state.highlight]
+				listContainer.open = false
+				c.consumeKey(ebiten.KeyEnter)
+			}
+		}
+		// The keys closing the list must not be handled by others, e.g. a modal window including the combo box.
+		if c.isKeyJustPressed(ebiten.KeyEscape) {
+			listContainer.open = false
+			c.consumeKey(ebiten.KeyEscape)
+		}
+	}
+
+	if err := c.window("", image.Rectangle{}, optionNoResize|optionNoTitle|optionNoScroll, idPart, func(layout ContainerLayout) {
+		c.bringToFront(listContainer)
+		c.SetGridLayout([]int{-1}, []int{0, -1})
+		_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+			return c.textField(&state.filter, filterID, 0)
+		})
+		_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+			if err := c.panel(0, idPartFromString("list"), func(layout ContainerLayout) {
+				c.comboBoxList(selectedIndex, options, state, id, listContainer)
+			}); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return c.widget(id, optionAlignCenter, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		var e EventHandler
+
+		// Close the list when elsewhere is clicked.
+		if listContainer.open && c.pointing.justPressed() {
+			p := c.pointingPosition()
+			if !p.In(bounds) && !p.In(listContainer.layout.Bounds) {
+				listContainer.open = false
+			}
+		}
+
+		if c.pointing.justPressed() && c.focus == id {
+			if listContainer.open {
+				listContainer.open = false
+			} else {
+				listContainer.open = true
+
+				rowCount := min(len(options), comboBoxMaxVisibleItems)
+				rowHeight := c.style().defaultHeight + c.style().spacing
+				height := c.style().padding*4 + rowHeight*(rowCount+1)
+				pos := image.Pt(bounds.Min.X, bounds.Max.Y)
+				listContainer.layout.Bounds = image.Rectangle{
+					Min: pos,
+					Max: pos.Add(image.Pt(bounds.Dx(), height)),
+				}
+
+				// Start with an empty filter and highlight the selected option.
+				state.filter = ""
+				state.items = filterOptions(state.items[:0], options, "")
+				state.itemsValid = true
+				state.lastFilter = ""
+				state.highlight = *selectedIndex
+				state.scrollToHighlight = true
+				if f := listContainer.textInputTextField(filterID, false); f != nil {
+					f.SetTextAndSelection("", 0, 0)
+				}
+				c.setFocus(filterID)
+			}
+		}
+		if !listContainer.open {
+			state.itemsValid = false
+		}
+
+		if last != *selectedIndex {
+			e = &eventHandler{}
+		}
+		return e
+	}, func(bounds image.Rectangle) {
+		c.drawWidgetFrame(id, bounds, colorButton, optionAlignCenter)
+
+		arrowWidth := bounds.Dy()
+		textBounds := bounds
+		textBounds.Max.X -= arrowWidth
+		c.drawTruncatedWidgetText(options[*selectedIndex], textBounds, colorText, optionAlignCenter)
+
+		arrowBounds := image.Rect(bounds.Max.X-arrowWidth, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		icon := iconDown
+		if listContainer.open {
+			icon = iconUp
+		}
+		c.drawIcon(icon, arrowBounds, c.style().colors[colorText])
+	})
+}
+
+func (c *Context) comboBoxList(selectedIndex *int, options []string, state *comboBoxState, id widgetID, listContainer *container) {
+	c.SetGridLayout([]int{-1}, nil)
+	rowHeight := c.style().defaultHeight
+
+	if state.scrollToHighlight {
+		cnt := c.currentContainer()
+		stride := rowHeight + c.style().spacing
+		top := state.highlight * stride
+		viewHeight := cnt.layout.BodyBounds.Dy() - c.style().padding*2
+		scroll := cnt.layout.ScrollOffset
+		if top < scroll.Y {
+			scroll.Y = top
+		} else if top+rowHeight > scroll.Y+viewHeight {
+			scroll.Y = top + rowHeight - viewHeight
+		}
+		cnt.layout.ScrollOffset = scroll
+		state.scrollToHighlight = false
+	}
+
+	itemID := id.push(idPartFromString("item"))
+	if err := c.virtualRows(len(state.items), rowHeight, func(i int) {
+		index := state.items[i]
+		rowID := itemID.push(idPartFromInt(index))
+		_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+			return c.widget(rowID, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+				if c.pointing.justPressed() && c.focus == rowID {
+					*selectedIndex = index
+					listContainer.open = false
+				}
+				return nil
+			}, func(bounds image.Rectangle) {
+				if i == state.highlight {
+					c.drawFrame(bounds, colorButtonFocus)
+				} else {
+					c.drawWidgetFrame(rowID, bounds, colorButton, 0)
+				}
+				c.drawWidgetText(options[index], bounds, colorText, 0)
+			})
+		})
+	}); err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// filterOptions appends the indices of options matching query to items, and returns the extended slice.
+//
+// The indices are sorted by the rank of the match. Options with the same rank keep their original order.
+func filterOptions(items []int, options []string, query string) []int {
+	query = strings.ToLower(query)
+	start := len(items)
+	ranks := map[int]int{}
+	for i, option := range options {
+		rank, ok := matchOption(strings.ToLower(option), query)
+		if !ok {
+			continue
+		}
+		items = append(items, i)
+		ranks[i] = rank
+	}
+	slices.SortStableFunc(items[start:], func(a, b int) int {
+		return ranks[a] - ranks[b]
+	})
+	return items
+}
+
+// matchOption reports whether option matches query, and returns the rank of the match.
+//
+// A smaller rank means a better match:
+// 0 for a prefix match, 1 for a substring match, and 2 for a subsequence match.
+func matchOption(option, query string) (rank int, ok bool) {
+	if strings.HasPrefix(option, query) {
+		return 0, true
+	}
+	if strings.Contains(option, query) {
+		return 1, true
+	}
+	for _, r := range option {
+		if query == "" {
+			break
+		}
+		q, size := utf8.DecodeRuneInString(query)
+		if r == q {
+			query = query[size:]
+		}
+	}
+	if query == "" {
+		return 2, true
+	}
+	return 0, false
+}
@@ -32,6 +32,7 @@ type textCommand struct {
 	pos   image.Point
 	color color.Color
 	str   string
+	bold  bool
 }
 
 type iconCommand struct {
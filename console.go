@@ -0,0 +1,530 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const consoleHistorySize = 100
+
+// ConsoleArgType represents the type of a console command argument.
+type ConsoleArgType int
+
+const (
+	// ConsoleArgString is a string argument. The value is passed as a string.
+	ConsoleArgString ConsoleArgType = iota
+
+	// ConsoleArgInt is an integer argument. The value is passed as an int.
+	ConsoleArgInt
+
+	// ConsoleArgFloat is a floating-point argument. The value is passed as a float64.
+	ConsoleArgFloat
+
+	// ConsoleArgBool is a boolean argument. The value is passed as a bool.
+	ConsoleArgBool
+)
+
+// String returns the name of the type.
+func (t ConsoleArgType) String() string {
+	switch t {
+	case ConsoleArgString:
+		return "string"
+	case ConsoleArgInt:
+		return "int"
+	case ConsoleArgFloat:
+		return "float"
+	case ConsoleArgBool:
+		return "bool"
+	default:
+		return "ConsoleArgType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// ConsoleArg represents an argument of a console command.
+type ConsoleArg struct {
+	// Name is the name of the argument shown in the help text.
+	Name string
+
+	// Type is the type of the argument.
+	Type ConsoleArgType
+
+	// Optional specifies whether the argument can be omitted.
+	// Optional arguments must follow the required arguments.
+	Optional bool
+
+	// Values is the allowed values of a string argument.
+	// Values are also used for tab completion.
+	//
+	// If Values is empty, any string is allowed.
+	Values []string
+}
+
+// ConsoleCommand represents a command of a console.
+type ConsoleCommand struct {
+	// Name is the name of the command. Name must not be empty or contain spaces.
+	Name string
+
+	// Help is the help text of the command.
+	Help string
+
+	// Args is the arguments of the command.
+	Args []ConsoleArg
+
+	// Run is called when the command is executed.
+	//
+	// args has a value for each given argument in the order of Args.
+	// The type of each value is string, int, float64 or bool according to the type of the argument.
+	// Omitted optional arguments are not included in args.
+	//
+	// An error returned by Run is shown in the console output.
+	Run func(args []any) error
+}
+
+func (cmd *ConsoleCommand) usage() string {
+	var b strings.Builder
+	b.WriteString(cmd.Name)
+	for _, arg := range cmd.Args {
+		b.WriteString(" ")
+		left, right := "<", ">"
+		if arg.Optional {
+			left, right = "[", "]"
+		}
+		b.WriteString(left)
+		b.WriteString(arg.Name)
+		switch {
+		case len(arg.Values) > 0:
+			b.WriteString(":")
+			b.WriteString(strings.Join(arg.Values, "|"))
+		case arg.Type != ConsoleArgString:
+			b.WriteString(":")
+			b.WriteString(arg.Type.String())
+		}
+		b.WriteString(right)
+	}
+	return b.String()
+}
+
+// Console is a set of commands executed from a console widget, and its output.
+//
+// The commands "help" and "clear" are built in.
+//
+// The zero value of Console is ready to use.
+type Console struct {
+	commands map[string]*ConsoleCommand
+	output   LogBuffer
+
+	// history is the executed lines from the oldest to the newest.
+	history []string
+
+	// historyIndex is the index of the history entry shown in the input.
+	// historyIndex equals to len(history) when the input is not from the history.
+	historyIndex int
+
+	// draft is the input before navigating the history.
+	draft string
+
+	// input is the text of the input field.
+	input string
+}
+
+func (c *Console) ensureCommands() {
+	if c.commands != nil {
+		return
+	}
+	c.commands = map[string]*ConsoleCommand{}
+	c.commands["help"] = &ConsoleCommand{
+		Name: "help",
+		Help: "Shows the help of the commands.",
+		Args: []ConsoleArg{
+			{Name: "command", Optional: true},
+		},
+		Run: func(args []any) error {
+			if len(args) > 0 {
+				cmd, ok := c.commands[args[0].(string)]
+				if !ok {
+					return fmt.Errorf("unknown command: %s", args[0])
+				}
+				c.Print(cmd.usage())
+				if cmd.Help != "" {
+					c.Print("  " + cmd.Help)
+				}
+				return nil
+			}
+			for _, name := range slices.Sorted(maps.Keys(c.commands)) {
+				cmd := c.commands[name]
+				if cmd.Help != "" {
+					c.Print(cmd.usage() + " - " + cmd.Help)
+				} else {
+					c.Print(cmd.usage())
+				}
+			}
+			return nil
+		},
+	}
+	c.commands["clear"] = &ConsoleCommand{
+		Name: "clear",
+		Help: "Clears the output.",
+		Run: func(args []any) error {
+			c.output.Clear()
+			return nil
+		},
+	}
+}
+
+// Register registers a command.
+//
+// Register returns an error if the command is invalid or a command with the same name is already registered.
+func (c *Console) Register(command ConsoleCommand) error {
+	if command.Name == "" || strings.ContainsFunc(command.Name, unicode.IsSpace) {
+		return fmt.Errorf("debugui: invalid command name: %q", command.Name)
+	}
+	if command.Run == nil {
+		return fmt.Errorf("debugui: Run must not be nil: %s", command.Name)
+	}
+	for i, arg := range command.Args {
+		if i > 0 && command.Args[i-1].Optional && !arg.Optional {
+			return fmt.Errorf("debugui: a required argument %s must not follow an optional argument: %s", arg.Name, command.Name)
+		}
+	}
+
+	c.ensureCommands()
+	if _, ok := c.commands[command.Name]; ok {
+		return fmt.Errorf("debugui: command %s is already registered", command.Name)
+	}
+	c.commands[command.Name] = &command
+	return nil
+}
+
+// Output returns the log buffer of the console output.
+func (c *Console) Output() *LogBuffer {
+	return &c.output
+}
+
+// Print adds a message to the console output.
+func (c *Console) Print(message string) {
+	c.output.Add(LogLevelInfo, "", message)
+}
+
+// Printf adds a formatted message to the console output.
+func (c *Console) Printf(format string, args ...any) {
+	c.Print(fmt.Sprintf(format, args...))
+}
+
+// Execute executes a command line such as "spawn goblin 10".
+//
+// Arguments are separated by spaces. An argument including spaces can be quoted with double quotes.
+//
+// The line and an error, if any, are shown in the console output.
+// Execute returns an error if the line is invalid or the command fails.
+func (c *Console) Execute(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	c.output.Add(LogLevelDebug, "", "> "+line)
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > consoleHistorySize {
+			c.history = slices.Delete(c.history, 0, len(c.history)-consoleHistorySize)
+		}
+	}
+	c.historyIndex = len(c.history)
+	c.draft = ""
+
+	if err := c.execute(line); err != nil {
+		c.output.Add(LogLevelError, "", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *Console) execute(line string) error {
+	tokens, err := splitConsoleLine(line)
+	if err != nil {
+		return err
+	}
+
+	c.ensureCommands()
+	cmd, ok := c.commands[tokens[0]]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", tokens[0])
+	}
+
+	strs := tokens[1:]
+	var required int
+	for _, arg := range cmd.Args {
+		if !arg.Optional {
+			required++
+		}
+	}
+	if len(strs) < required || len(strs) > len(cmd.Args) {
+		return fmt.Errorf("usage: %s", cmd.usage())
+	}
+
+	args := make([]any, len(strs))
+	for i, str := range strs {
+		v, err := parseConsoleArg(&cmd.Args[i], str)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Name, err)
+		}
+		args[i] = v
+	}
+	return cmd.Run(args)
+}
+
+func parseConsoleArg(arg *ConsoleArg, str string) (any, error) {
+	switch arg.Type {
+	case ConsoleArgInt:
+		v, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid int: %q", arg.Name, str)
+		}
+		return v, nil
+	case ConsoleArgFloat:
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid float: %q", arg.Name, str)
+		}
+		return v, nil
+	case ConsoleArgBool:
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid bool: %q", arg.Name, str)
+		}
+		return v, nil
+	default:
+		if len(arg.Values) > 0 && !slices.Contains(arg.Values, str) {
+			return nil, fmt.Errorf("%s: must be one of %s: %q", arg.Name, strings.Join(arg.Values, ", "), str)
+		}
+		return str, nil
+	}
+}
+
+// splitConsoleLine splits a command line into tokens separated by spaces.
+// A token can be quoted with double quotes, and a backslash escapes the next character in quotes.
+func splitConsoleLine(line string) ([]string, error) {
+	var tokens []string
+	var b strings.Builder
+	var inToken, quoted bool
+	for i := 0; i < len(line); {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case r == '\\' && quoted && i < len(line):
+			r, size := utf8.DecodeRuneInString(line[i:])
+			i += size
+			b.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if inToken {
+				tokens = append(tokens, b.String())
+				b.Reset()
+				inToken = false
+			}
+		default:
+			b.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		tokens = append(tokens, b.String())
+	}
+	return tokens, nil
+}
+
+// lastConsoleTokenStart returns the byte index where the last token of the line starts.
+// If the line ends with a space, lastConsoleTokenStart returns the length of the line.
+func lastConsoleTokenStart(line string) int {
+	var start int
+	var quoted bool
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return start
+}
+
+// Complete completes the last token of the line with the command names or the argument values.
+//
+// Complete returns the completed line and the candidates for the last token.
+// If there is exactly one candidate, the token is replaced with it followed by a space.
+// If there are multiple candidates, the token is extended to their longest common prefix.
+func (c *Console) Complete(line string) (string, []string) {
+	c.ensureCommands()
+
+	start := lastConsoleTokenStart(line)
+	prev, err := splitConsoleLine(line[:start])
+	if err != nil {
+		return line, nil
+	}
+	prefix := strings.ReplaceAll(line[start:], `"`, "")
+
+	var values []string
+	if len(prev) == 0 {
+		values = slices.Sorted(maps.Keys(c.commands))
+	} else if cmd, ok := c.commands[prev[0]]; ok && len(prev)-1 < len(cmd.Args) {
+		arg := &cmd.Args[len(prev)-1]
+		switch {
+		case cmd.Name == "help" && arg.Name == "command":
+			values = slices.Sorted(maps.Keys(c.commands))
+		case arg.Type == ConsoleArgBool:
+			values = []string{"false", "true"}
+		default:
+			values = arg.Values
+		}
+	}
+
+	var candidates []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			candidates = append(candidates, v)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return line, nil
+	case 1:
+		return line[:start] + quoteConsoleToken(candidates[0]) + " ", candidates
+	}
+
+	common := candidates[0]
+	for _, v := range candidates[1:] {
+		for !strings.HasPrefix(v, common) {
+			_, size := utf8.DecodeLastRuneInString(common)
+			common = common[:len(common)-size]
+		}
+	}
+	if len(common) <= len(prefix) {
+		return line, candidates
+	}
+	return line[:start] + quoteConsoleToken(common), candidates
+}
+
+func quoteConsoleToken(str string) string {
+	if str != "" && !strings.ContainsFunc(str, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '\\'
+	}) {
+		return str
+	}
+	return strconv.Quote(str)
+}
+
+func (c *Console) previousHistory(current string) string {
+	if c.historyIndex > len(c.history) {
+		c.historyIndex = len(c.history)
+	}
+	if c.historyIndex == len(c.history) {
+		c.draft = current
+	}
+	if c.historyIndex == 0 {
+		return current
+	}
+	c.historyIndex--
+	return c.history[c.historyIndex]
+}
+
+func (c *Console) nextHistory(current string) string {
+	if c.historyIndex >= len(c.history) {
+		return current
+	}
+	c.historyIndex++
+	if c.historyIndex == len(c.history) {
+		return c.draft
+	}
+	return c.history[c.historyIndex]
+}
+
+// Console creates a console widget with the output of the console and an input field.
+//
+// The console occupies one grid cell. Use [SetGridLayout] to specify the size of the console.
+// Pressing the Enter key in the input field executes the line.
+// The Up and Down keys navigate the history, and the Tab key completes the command names and the argument values.
+//
+// A Console widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Console(console *Console) {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			err = c.console(console, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+func (c *Context) console(console *Console, id widgetID) error {
+	var err error
+	c.GridCell(func(bounds image.Rectangle) {
+		inputHeight := c.style().defaultHeight
+		if err = c.setGridLayout([]int{-1}, []int{max(bounds.Dy()-inputHeight-c.style().spacing, inputHeight)}); err != nil {
+			return
+		}
+		if err = c.log(&console.output, nil, id.push(idPartFromString("output"))); err != nil {
+			return
+		}
+
+		if err = c.setGridLayout([]int{-1}, []int{inputHeight}); err != nil {
+			return
+		}
+		inputID := id.push(idPartFromString("input"))
+		e, err2 := c.textField(&console.input, inputID, 0)
+		if err2 != nil {
+			err = err2
+			return
+		}
+		if c.focus != inputID {
+			return
+		}
+
+		setInput := func(str string) {
+			console.input = str
+			if f := c.currentContainer().textInputTextField(inputID, false); f != nil {
+				f.SetTextAndSelection(str, len(str), len(str))
+			}
+		}
+		switch {
+		case e != nil:
+			// The error is shown in the output.
+			_ = console.Execute(console.input)
+			setInput("")
+		case c.keyRepeated(ebiten.KeyUp):
+			setInput(console.previousHistory(console.input))
+		case c.keyRepeated(ebiten.KeyDown):
+			setInput(console.nextHistory(console.input))
+		case c.isKeyJustPressed(ebiten.KeyTab):
+			line, candidates := console.Complete(console.input)
+			if len(candidates) > 1 {
+				console.Print(strings.Join(candidates, "  "))
+			}
+			setInput(line)
+		}
+	})
+	return err
+}
@@ -7,12 +7,24 @@ import (
 	"image"
 	"slices"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
 )
 
 type container struct {
 	parent *container
 
+	// owner is the root container in which this root container is declared.
+	// owner is nil if this container is declared at the top level.
+	//
+	// owner is valid only for root containers.
+	owner *container
+
+	// opt is the option of the window.
+	//
+	// opt is valid only for root containers.
+	opt option
+
 	layout    ContainerLayout
 	open      bool
 	collapsed bool
@@ -27,6 +39,33 @@ type container struct {
 	// dropdownCloseDelay is used for delayed closing of dropdowns
 	dropdownCloseDelay int
 
+	// widgetStates is the states of the widgets using the container, keyed by the widget IDs.
+	//
+	// Use widgetState to get a state.
+	widgetStates map[widgetID]any
+
+	// initialBounds is the initial bounds of the window.
+	//
+	// initialBounds is valid only for root containers.
+	initialBounds image.Rectangle
+
+	// undockedBounds is the bounds of the window before the window is docked.
+	undockedBounds image.Rectangle
+
+	// docked reports whether the window is docked.
+	docked bool
+
+	// dragBounds is the bounds of the window being dragged or resized before snapping or constraining.
+	dragBounds image.Rectangle
+
+	// resizeEdges is the edges of the window being resized.
+	resizeEdges resizeEdge
+
+	// minSize, maxSize and aspectRatio are the constraints of the window size for resizing.
+	minSize     image.Point
+	maxSize     image.Point
+	aspectRatio float64
+
 	used bool
 }
 
@@ -78,19 +117,216 @@ func (c *Context) currentRootContainer() *container {
 	return cnt
 }
 
-// Window creates a new window with the contents defined by the function f.
+// WindowID is the ID of a window.
+//
+// A WindowID is returned by the functions creating a window, like Window.
+// Like a widget's ID, a WindowID depends on the call site of the function and the current ID scope,
+// so the same WindowID is returned at every tick as long as the window is created at the same place.
+type WindowID widgetID
+
+// Window creates a new window with the contents defined by the function f,
+// and returns the WindowID of the window.
 //
 // title is the title of the window.
 // rect is the initial size and position of the window.
-func (c *Context) Window(title string, initialBounds image.Rectangle, f func(layout ContainerLayout)) {
+//
+// The returned WindowID can be used to control the window, e.g. by SetWindowBounds or CloseWindow.
+func (c *Context) Window(title string, initialBounds image.Rectangle, f func(layout ContainerLayout)) WindowID {
 	pc := caller()
 	idPart := idPartFromCaller(pc)
+	id := c.idStack.push(idPart)
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		if err := c.window(title, initialBounds, 0, idPart, f); err != nil {
 			return nil, err
 		}
 		return nil, nil
 	})
+	return WindowID(id)
+}
+
+// WindowOptions represents options for a window.
+type WindowOptions struct {
+	// NoTitle specifies whether the title bar is hidden.
+	// A window without the title bar cannot be moved or collapsed by the user.
+	NoTitle bool
+
+	// NoResize specifies whether the resize handle is hidden.
+	NoResize bool
+
+	// AutoSize specifies whether the window is resized to fit its content every frame.
+	AutoSize bool
+
+	// NoScroll specifies whether the scroll bars are hidden.
+	NoScroll bool
+
+	// NoFrame specifies whether the window background is not drawn.
+	NoFrame bool
+
+	// MinSize is the minimum size of the window when the window is resized by the user.
+	// If a component of MinSize is 0 or negative, the default minimum size is used.
+	MinSize image.Point
+
+	// MaxSize is the maximum size of the window when the window is resized by the user.
+	// If a component of MaxSize is 0 or negative, the size is not limited except by the screen.
+	MaxSize image.Point
+
+	// AspectRatio is the ratio of the width to the height kept when the window is resized by the user.
+	// If AspectRatio is 0 or negative, the aspect ratio is not locked.
+	AspectRatio float64
+
+	// Open specifies whether the window is shown.
+	// If Open is not nil, the window has a close button in the title bar, and *Open is set to false when the button is clicked.
+	// To show the window again, set *Open to true.
+	Open *bool
+}
+
+func (o *WindowOptions) option() option {
+	if o == nil {
+		return 0
+	}
+	var opt option
+	if o.NoTitle {
+		opt |= optionNoTitle
+	}
+	if o.NoResize {
+		opt |= optionNoResize
+	}
+	if o.AutoSize {
+		opt |= optionAutoSize
+	}
+	if o.NoScroll {
+		opt |= optionNoScroll
+	}
+	if o.NoFrame {
+		opt |= optionNoFrame
+	}
+	if o.Open != nil {
+		opt |= optionCloseButton
+	}
+	return opt
+}
+
+// WindowWithOptions creates a new window with the given options, and returns the WindowID of the window.
+//
+// options can be nil. The other parameters are the same as Window.
+//
+// For example, WindowWithOptions with NoTitle and NoFrame creates a borderless overlay like a HUD,
+// and WindowWithOptions with Open creates a window that the user can close.
+func (c *Context) WindowWithOptions(title string, initialBounds image.Rectangle, options *WindowOptions, f func(layout ContainerLayout)) WindowID {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		// The size limits are updated at every call, so that the limits removed from options are not kept.
+		var o WindowOptions
+		if options != nil {
+			o = *options
+		}
+		cnt := c.container(id, 0)
+		cnt.minSize = o.MinSize
+		cnt.maxSize = o.MaxSize
+		cnt.aspectRatio = o.AspectRatio
+		if o.Open != nil {
+			cnt.open = *o.Open
+		}
+		if err := c.window(title, initialBounds, options.option(), idPart, f); err != nil {
+			return nil, err
+		}
+		if o.Open != nil {
+			*o.Open = cnt.open
+		}
+		return nil, nil
+	})
+	return WindowID(id)
+}
+
+// windowContainer returns the container of the window, or nil if the window doesn't exist.
+//
+// Unlike container, windowContainer doesn't create a container for an unknown ID,
+// and doesn't mark the container as used, so controlling a window doesn't keep a window that is no longer declared.
+func (c *Context) windowContainer(windowID WindowID) *container {
+	return c.idToContainer[widgetID(windowID)]
+}
+
+// SetWindowBounds sets the bounds of a window.
+//
+// The functions to control a window by WindowID, like SetWindowBounds, do nothing if the window doesn't exist.
+// Like any other window, a window that is not declared in a tick is removed at the end of the tick,
+// even if the window is controlled in the tick.
+func (c *Context) SetWindowBounds(windowID WindowID, bounds image.Rectangle) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.windowContainer(windowID)
+		if cnt == nil {
+			return nil, nil
+		}
+		cnt.layout.Bounds = bounds
+		return nil, nil
+	})
+}
+
+// SetWindowCollapsed collapses or expands a window.
+func (c *Context) SetWindowCollapsed(windowID WindowID, collapsed bool) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.windowContainer(windowID)
+		if cnt == nil {
+			return nil, nil
+		}
+		cnt.collapsed = collapsed
+		return nil, nil
+	})
+}
+
+// OpenWindow opens a window closed by CloseWindow, and brings the window to front.
+func (c *Context) OpenWindow(windowID WindowID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.windowContainer(windowID)
+		if cnt == nil {
+			return nil, nil
+		}
+		cnt.open = true
+		c.bringToFront(cnt)
+		return nil, nil
+	})
+}
+
+// CloseWindow closes a window.
+//
+// A closed window is not shown even though Window is called, until OpenWindow is called.
+func (c *Context) CloseWindow(windowID WindowID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.windowContainer(windowID)
+		if cnt == nil {
+			return nil, nil
+		}
+		cnt.open = false
+		return nil, nil
+	})
+}
+
+// BringWindowToFront brings a window to front.
+func (c *Context) BringWindowToFront(windowID WindowID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.windowContainer(windowID)
+		if cnt == nil {
+			return nil, nil
+		}
+		c.bringToFront(cnt)
+		return nil, nil
+	})
+}
+
+// IsWindowOpen reports whether a window is open.
+//
+// IsWindowOpen returns false if the window has never been created.
+func (c *Context) IsWindowOpen(windowID WindowID) bool {
+	cnt, ok := c.idToContainer[widgetID(windowID)]
+	return ok && cnt.open
+}
+
+// IsWindowHovered reports whether the pointing device is on a window and the window is not hidden by another window.
+func (c *Context) IsWindowHovered(windowID WindowID) bool {
+	cnt, ok := c.idToContainer[widgetID(windowID)]
+	return ok && cnt.open && c.hoveringRootContainer() == cnt
 }
 
 func (c *Context) window(title string, initialBounds image.Rectangle, opt option, idPart string, f func(layout ContainerLayout)) error {
@@ -112,6 +348,30 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 		cnt.layout.Bounds = initialBounds
 	}
 
+	cnt.opt = opt
+	cnt.initialBounds = initialBounds
+	cnt.owner = nil
+	if len(c.containerStack) > 0 {
+		cnt.owner = c.currentRootContainer()
+	}
+
+	var leaf *dockNode
+	cnt.docked = false
+	if c.dock.enabled && dockable(opt) {
+		leaf = c.registerDockWindow(title, cnt, id)
+		if leaf != nil {
+			// Only the active tab is shown.
+			if leaf.activeWindow() != id {
+				cnt.layout.Bounds = image.Rectangle{}
+				return nil
+			}
+			cnt.layout.Bounds = leaf.bounds
+			cnt.docked = true
+			cnt.collapsed = false
+			opt |= optionNoResize | optionNoClose
+		}
+	}
+
 	c.pushContainer(cnt, true)
 	defer c.popContainer()
 
@@ -125,6 +385,29 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 	c.clipStack = append(c.clipStack, unclippedRect)
 	defer c.popClipRect()
 
+	// draw the preview of the dock target over the window content
+	var dockTarget dockTarget
+	var hasDockTarget bool
+	defer func() {
+		if hasDockTarget {
+			c.drawRect(c.dockTargetBounds(dockTarget), c.style().colors[colorDockPreview])
+		}
+	}()
+
+	if (opt & optionModal) != 0 {
+		// dim the whole screen behind the modal window
+		screen := c.screenBounds()
+		c.drawRect(screen, c.style().colors[colorModalBG])
+		if !screen.Empty() {
+			b := cnt.layout.Bounds
+			pos := screen.Min.Add(screen.Size().Sub(b.Size()).Div(2))
+			cnt.layout.Bounds = b.Add(pos.Sub(b.Min))
+		}
+	}
+	if (opt & optionTooltip) != 0 {
+		c.placeTooltip(cnt)
+	}
+
 	body := cnt.layout.Bounds
 	bounds := body
 
@@ -145,16 +428,36 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 		}
 
 		// do title text
-		if (^opt & optionNoTitle) != 0 {
+		if leaf != nil && len(c.dockTabWindows(leaf)) > 1 {
+			r := tr
+			if (opt & optionCloseButton) != 0 {
+				r.Max.X -= tr.Dy()
+			}
+			c.dockTabs(leaf, r)
+			body.Min.Y += tr.Dy()
+		} else if (^opt & optionNoTitle) != 0 {
 			titleID := id.push(idPartFromString("title"))
 			r := image.Rect(tr.Min.X+tr.Dy()-c.style().padding, tr.Min.Y, tr.Max.X, tr.Max.Y)
+			if (opt & optionCloseButton) != 0 {
+				r.Max.X -= tr.Dy()
+			}
 			_ = c.widgetWithBounds(titleID, opt, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
-				if titleID == c.focus && c.pointing.pressed() {
-					b := cnt.layout.Bounds.Add(c.pointingDelta())
+				if leaf != nil {
+					// Dragging the title bar of a docked window undocks the window.
+					if titleID == c.focus && c.pointing.pressed() && c.pointingDelta() != (image.Point{}) {
+						c.undockWindow(id)
+					}
+				} else if titleID == c.focus && c.pointing.pressed() && (opt&optionModal) == 0 {
+					// Keep the dragged bounds before snapping, so that a snapped window doesn't stick to the edge.
+					if c.pointing.justPressed() {
+						cnt.dragBounds = cnt.layout.Bounds
+					}
+					cnt.dragBounds = cnt.dragBounds.Add(c.pointingDelta())
+					b := c.snapWindowBounds(cnt, cnt.dragBounds)
 					if c.screenWidth > 0 {
 						maxX := b.Max.X
-						if maxX >= c.screenWidth/c.Scale() {
-							b = b.Add(image.Pt(c.screenWidth/c.Scale()-maxX, 0))
+						if maxX >= c.screenBounds().Max.X {
+							b = b.Add(image.Pt(c.screenBounds().Max.X-maxX, 0))
 						}
 					}
 					if b.Min.X < 0 {
@@ -162,19 +465,26 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 					}
 					if c.screenHeight > 0 {
 						maxY := b.Min.Y + tr.Dy()
-						if maxY >= c.screenHeight/c.Scale()-c.style().padding {
-							b = b.Add(image.Pt(0, c.screenHeight/c.Scale()-maxY))
+						if maxY >= c.screenBounds().Max.Y-c.style().padding {
+							b = b.Add(image.Pt(0, c.screenBounds().Max.Y-maxY))
 						}
 					}
 					if b.Min.Y < 0 {
 						b = b.Add(image.Pt(0, -b.Min.Y))
 					}
 					cnt.layout.Bounds = b
+					if c.dock.enabled && dockable(opt) {
+						dockTarget, hasDockTarget = c.dockTargetAt(c.pointingPosition())
+					}
+				} else if wasFocused && leaf == nil && c.dock.enabled && dockable(opt) {
+					if target, ok := c.dockTargetAt(c.pointingPosition()); ok {
+						c.dockWindow(id, title, cnt, target)
+					}
 				}
 				body.Min.Y += tr.Dy()
 				return nil
 			}, func(bounds image.Rectangle) {
-				c.drawWidgetText(title, r, colorTitleText, opt)
+				c.drawTruncatedWidgetText(title, r, colorTitleText, opt)
 			})
 		}
 
@@ -195,6 +505,20 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 				c.drawIcon(icon, r, c.style().colors[colorTitleText])
 			})
 		}
+
+		// do `close` button
+		if (opt & optionCloseButton) != 0 {
+			closeID := id.push(idPartFromString("close"))
+			r := image.Rect(tr.Max.X-tr.Dy(), tr.Min.Y, tr.Max.X, tr.Max.Y)
+			_ = c.widgetWithBounds(closeID, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+				if c.pointing.justPressed() && closeID == c.focus {
+					cnt.open = false
+				}
+				return nil
+			}, func(bounds image.Rectangle) {
+				c.drawIcon(iconClose, r, c.style().colors[colorTitleText])
+			})
+		}
 	}
 
 	if collapsed {
@@ -210,18 +534,9 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 		}
 	}()
 
-	// do `resize` handle
+	// do `resize` handles
 	if (^opt & optionNoResize) != 0 {
-		sz := c.style().titleHeight
-		resizeID := id.push(idPartFromString("resize"))
-		r := image.Rect(bounds.Max.X-sz, bounds.Max.Y-sz, bounds.Max.X, bounds.Max.Y)
-		_ = c.widgetWithBounds(resizeID, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
-			if resizeID == c.focus && c.pointing.pressed() {
-				cnt.layout.Bounds.Max.X = min(cnt.layout.Bounds.Min.X+max(96, cnt.layout.Bounds.Dx()+c.pointingDelta().X), c.screenWidth/c.Scale())
-				cnt.layout.Bounds.Max.Y = min(cnt.layout.Bounds.Min.Y+max(64, cnt.layout.Bounds.Dy()+c.pointingDelta().Y), c.screenHeight/c.Scale())
-			}
-			return nil
-		}, nil)
+		c.resizeHandles(cnt, id.push(idPartFromString("resize")), bounds)
 	}
 
 	// resize to content size
@@ -245,6 +560,11 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 
 	f(c.currentContainer().layout)
 
+	// The Escape key is checked after the content, so that a widget in the modal window can consume the key.
+	if (opt&optionModal) != 0 && c.topModal() == cnt && c.isKeyJustPressed(ebiten.KeyEscape) {
+		cnt.open = false
+	}
+
 	return nil
 }
 
@@ -281,6 +601,9 @@ func (c *Context) ClosePopup(popupID PopupID) {
 //
 // By default, the popup window is hidden.
 // To show the popup window, call OpenPopup with the PopupID returned by this function.
+// The popup window is closed when elsewhere is clicked, or when ClosePopup is called.
+//
+// Like a WindowID, the same PopupID is returned at every tick as long as Popup is called at the same place.
 func (c *Context) Popup(f func(layout ContainerLayout, popupID PopupID)) PopupID {
 	pc := caller()
 	idPart := idPartFromCaller(pc)
@@ -297,6 +620,59 @@ func (c *Context) Popup(f func(layout ContainerLayout, popupID PopupID)) PopupID
 	return PopupID(id)
 }
 
+// ModalID is the ID of a modal window.
+type ModalID widgetID
+
+// OpenModal opens a modal window.
+//
+// While a modal window is open, the other windows don't receive any input.
+func (c *Context) OpenModal(modalID ModalID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.container(widgetID(modalID), 0)
+		cnt.open = true
+		// The window options are set when the modal window is declared, which might be after the other windows in the next tick.
+		// Mark the container as modal now so that the other windows don't receive any input from the next tick.
+		cnt.opt |= optionModal
+		c.bringToFront(cnt)
+		return nil, nil
+	})
+}
+
+// CloseModal closes a modal window.
+func (c *Context) CloseModal(modalID ModalID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.container(widgetID(modalID), 0)
+		cnt.open = false
+		return nil, nil
+	})
+}
+
+// Modal creates a modal window with the given title and the content defined by the provided function,
+// and returns the ModalID of the modal window.
+//
+// By default, the modal window is hidden.
+// To show the modal window, call OpenModal with the ModalID returned by this function.
+//
+// A modal window is placed at the center of the screen and dims the other windows.
+// While a modal window is open, the other windows don't receive any input,
+// and Update reports [InputCapturingStateFocus].
+// A modal window is closed when the Escape key is pressed.
+func (c *Context) Modal(title string, f func(layout ContainerLayout, modalID ModalID)) ModalID {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		opt := optionModal | optionAutoSize | optionNoResize | optionNoScroll | optionNoClose | optionClosed
+		if err := c.window(title, image.Rectangle{}, opt, idPart, func(layout ContainerLayout) {
+			f(layout, ModalID(id))
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	return ModalID(id)
+}
+
 func (c *Context) pushContainer(cnt *container, root bool) {
 	if !root && len(c.containerStack) > 0 {
 		cnt.parent = c.containerStack[len(c.containerStack)-1]
@@ -324,6 +700,21 @@ func (c *Context) SetScroll(scroll image.Point) {
 	c.currentContainer().layout.ScrollOffset = scroll
 }
 
+// widgetState returns the state of the widget with the given ID in the container.
+//
+// If the container doesn't have the state yet, widgetState creates a zero state.
+func widgetState[T any](cnt *container, id widgetID) *T {
+	if s, ok := cnt.widgetStates[id].(*T); ok {
+		return s
+	}
+	if cnt.widgetStates == nil {
+		cnt.widgetStates = map[widgetID]any{}
+	}
+	s := new(T)
+	cnt.widgetStates[id] = s
+	return s
+}
+
 func (c *container) textInputTextField(id widgetID, createIfNeeded bool) *textinput.Field {
 	if id == (widgetID{}) {
 		return nil
@@ -369,16 +760,63 @@ func (c *Context) bringToFront(cnt *container) {
 	c.rootContainers = append(c.rootContainers, cnt)
 }
 
+func (c *Context) sendToBack(cnt *container) {
+	idx := slices.Index(c.rootContainers, cnt)
+	if idx == 0 {
+		return
+	}
+	if idx > 0 {
+		c.rootContainers = slices.Delete(c.rootContainers, idx, idx+1)
+	}
+	c.rootContainers = slices.Insert(c.rootContainers, 0, cnt)
+}
+
 func (c *Context) hoveringRootContainer() *container {
+	return c.rootContainerAt(func(cnt *container) image.Rectangle {
+		return cnt.layout.Bounds
+	})
+}
+
+// rootContainerAt returns the frontmost root container whose hit bounds include the pointing position.
+func (c *Context) rootContainerAt(hitBounds func(cnt *container) image.Rectangle) *container {
 	p := c.pointingPosition()
 	for i := len(c.rootContainers) - 1; i >= 0; i-- {
 		cnt := c.rootContainers[i]
 		if !cnt.open {
 			continue
 		}
-		if p.In(cnt.layout.Bounds) {
+		// A tooltip never takes the pointing device from the widget below.
+		if (cnt.opt & optionTooltip) != 0 {
+			continue
+		}
+		if p.In(hitBounds(cnt)) {
+			return cnt
+		}
+		// A modal window hides the windows behind it.
+		if (cnt.opt & optionModal) != 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// topModal returns the frontmost open modal window, or nil if there is no open modal window.
+func (c *Context) topModal() *container {
+	for i := len(c.rootContainers) - 1; i >= 0; i-- {
+		cnt := c.rootContainers[i]
+		if cnt.open && (cnt.opt&optionModal) != 0 {
 			return cnt
 		}
 	}
 	return nil
 }
+
+// belongsTo reports whether the root container c is root or is declared in root directly or indirectly.
+func (c *container) belongsTo(root *container) bool {
+	for cnt := c; cnt != nil; cnt = cnt.owner {
+		if cnt == root {
+			return true
+		}
+	}
+	return false
+}
@@ -8,9 +8,11 @@ import (
 	"image"
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/go-text/typesetting/segmenter"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 func clamp[T int | float64](x, a, b T) T {
@@ -21,10 +23,16 @@ func clamp[T int | float64](x, a, b T) T {
 type Context struct {
 	pointing pointing
 
-	scaleMinus1   int
+	// inputSource is the source of the input. If inputSource is nil, Ebitengine's input is used.
+	inputSource inputSource
+
+	scaleMinus1   float64
+	opacityMinus1 float64
 	hover         widgetID
 	focus         widgetID
 	currentID     widgetID
+	currentBounds image.Rectangle
+	widgetCount   int
 	keepFocus     bool
 	scrollTarget  *container
 	numberEditBuf string
@@ -52,12 +60,53 @@ type Context struct {
 
 	lastPointingPos image.Point
 
+	// tick is the number of updates, which is used for animations.
+	tick int
+
 	screenWidth  int
 	screenHeight int
 
 	segStack    []segmenter.Segmenter
 	segStackIdx int
 
+	tooltip         tooltip
+	tooltipDelay    time.Duration
+	hasTooltipDelay bool
+
+	dock dock
+
+	// shortcuts is the shortcuts used in the current tick to detect conflicts.
+	shortcuts map[shortcut]struct{}
+
+	// parsedShortcuts caches the parsed key chords.
+	parsedShortcuts map[string]shortcut
+
+	// consumedKeys is the keys handled in the current tick.
+	consumedKeys []ebiten.Key
+
+	// textFieldFocus is the ID of the text field that has focus lastly.
+	textFieldFocus widgetID
+
+	// deviceScaleFactorEnabled reports whether the UI is scaled by the device scale factor.
+	deviceScaleFactorEnabled bool
+
+	// deviceScaleFactor is the device scale factor of the current monitor.
+	// deviceScaleFactor is updated once per tick, as getting the monitor is not cheap.
+	deviceScaleFactor float64
+
+	// face is the font face. If face is nil, the default font face is used.
+	face text.Face
+
+	// currentStyle is the style calculated for the font face.
+	currentStyle *style
+
+	// boldFace is the font face for bold text. If boldFace is nil, bold text is rendered by drawing the text twice.
+	boldFace text.Face
+
+	// scaledFaces is the font faces scaled for rendering, and scaledFacesScale is their scale.
+	scaledFaces      map[text.Face]text.Face
+	scaledFacesScale float64
+
 	err error
 }
 
@@ -89,12 +138,34 @@ func (c *Context) wrapEventHandlerAndError(f func() (EventHandler, error)) Event
 	return e
 }
 
+// wrapWidgetEventHandlerAndError is like wrapEventHandlerAndError, but for a function creating a widget.
+//
+// The returned WidgetEventHandler sets a tooltip for the last widget created by f.
+// If f doesn't create any widget, the tooltip is ignored.
+func (c *Context) wrapWidgetEventHandlerAndError(f func() (EventHandler, error)) WidgetEventHandler {
+	count := c.widgetCount
+	e := c.wrapEventHandlerAndError(f)
+	var widget eventWidget
+	if c.err == nil && c.widgetCount != count {
+		widget = eventWidget{
+			ctx:    c,
+			bounds: c.currentBounds,
+		}
+	}
+	if e, ok := e.(*eventHandler); ok {
+		e.widget = widget
+		return e
+	}
+	return &nullEventHandler{widget: widget}
+}
+
 func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputCapturingState, err error) {
 	if c.err != nil {
 		return 0, c.err
 	}
 
-	c.pointing.update()
+	c.pointing.update(c.input())
+	c.updateDeviceScaleFactor()
 
 	c.beginUpdate()
 	defer func() {
@@ -109,6 +180,12 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 	if c.err != nil {
 		return 0, c.err
 	}
+	if err := c.tooltipWindow(); err != nil {
+		return 0, err
+	}
+	if c.updateDockSplitters() {
+		inputCapturingState |= InputCapturingStateHover
+	}
 
 	// Check whether the cursor is on any of the root containers.
 	pt := c.pointingPosition()
@@ -126,10 +203,23 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 	if c.focus != (widgetID{}) {
 		inputCapturingState |= InputCapturingStateFocus
 	}
+
+	// A modal window captures all the input.
+	if c.topModal() != nil {
+		inputCapturingState |= InputCapturingStateFocus
+	}
 	return inputCapturingState, nil
 }
 
+// screenBounds returns the bounds of the screen in the UI coordinate.
+//
+// screenBounds returns an empty rectangle if the screen size is not known yet.
+func (c *Context) screenBounds() image.Rectangle {
+	return image.Rect(0, 0, int(float64(c.screenWidth)/c.ScaleF()), int(float64(c.screenHeight)/c.ScaleF()))
+}
+
 func (c *Context) beginUpdate() {
+	c.tick++
 	for _, cnt := range c.idToContainer {
 		cnt.used = false
 	}
@@ -138,6 +228,10 @@ func (c *Context) beginUpdate() {
 	}
 	c.scrollTarget = nil
 	c.currentID = widgetID{}
+	c.currentBounds = image.Rectangle{}
+	clear(c.shortcuts)
+	c.consumedKeys = c.consumedKeys[:0]
+	c.beginDock()
 }
 
 func (c *Context) endUpdate() error {
@@ -157,7 +251,7 @@ func (c *Context) endUpdate() error {
 
 	// handle scroll input
 	if c.scrollTarget != nil {
-		wx, wy := ebiten.Wheel()
+		wx, wy := c.input().wheel()
 		c.scrollTarget.layout.ScrollOffset.X += int(wx * -30)
 		c.scrollTarget.layout.ScrollOffset.Y += int(wy * -30)
 	}
@@ -172,11 +266,15 @@ func (c *Context) endUpdate() error {
 	if c.pointing.justPressed() {
 		// TODO: When showing a popup, the position might be on the popup and the parent container might not be brought to front.
 		// Fix this issue.
-		if cnt := c.hoveringRootContainer(); cnt != nil {
+		// A click on the resize handles outside a window brings neither the window nor the window behind the handles to front.
+		if cnt := c.hoveringRootContainer(); cnt != nil && cnt == c.resizeHandleRootContainer() {
 			c.bringToFront(cnt)
 		}
 	}
 
+	c.updateTooltip()
+	c.endDock()
+
 	// reset input state
 	c.lastPointingPos = c.pointingPosition()
 
@@ -188,5 +286,21 @@ func (c *Context) endUpdate() error {
 		return !cnt.used
 	})
 
+	// Keep the frontmost modal window and the windows declared in it above the other windows.
+	if modal := c.topModal(); modal != nil {
+		rank := func(cnt *container) int {
+			if (cnt.opt & optionTooltip) != 0 {
+				return 2
+			}
+			if cnt.belongsTo(modal) {
+				return 1
+			}
+			return 0
+		}
+		slices.SortStableFunc(c.rootContainers, func(a, b *container) int {
+			return rank(a) - rank(b)
+		})
+	}
+
 	return nil
 }
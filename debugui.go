@@ -3,13 +3,24 @@
 
 package debugui
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
 
 // DebugUI is a debug UI.
 //
 // The zero value for DebugUI is ready to use.
 type DebugUI struct {
 	ctx Context
+
+	hidden bool
+
+	// toggleShortcut is the key chord to toggle the visibility. toggleShortcut is valid only when hasToggleShortcut is true.
+	toggleShortcut    shortcut
+	hasToggleShortcut bool
 }
 
 // InputCapturingState is a bit mask that indicates the input capturing state of the debug UI.
@@ -29,18 +40,117 @@ const (
 // Otherwise, Update returns false.
 //
 // Update should be called once in the game's Update function.
+//
+// While the debug UI is hidden, Update doesn't call f and returns 0 as the input capturing state.
 func (d *DebugUI) Update(f func(ctx *Context) error) (InputCapturingState, error) {
+	// Typing the key chord in a text field doesn't toggle the visibility.
+	if d.hasToggleShortcut && d.ctx.shortcutPressed(d.toggleShortcut) && (d.hidden || !d.ctx.textFieldFocused()) {
+		d.SetVisible(d.hidden)
+	}
+	if d.hidden {
+		return 0, nil
+	}
+
 	inputCapturingState, err := d.ctx.update(f)
 	if err != nil {
 		return 0, err
 	}
+	// The toggle key chord is checked before f, so a shortcut with the same chord would be triggered together.
+	if d.hasToggleShortcut {
+		if _, ok := d.ctx.shortcuts[d.toggleShortcut]; ok {
+			return 0, fmt.Errorf("debugui: shortcut %s is used as the toggle shortcut", d.toggleShortcut)
+		}
+	}
 	return inputCapturingState, nil
 }
 
 // Draw draws the debug UI.
 //
 // Draw should be called once in the game's Draw function.
+//
+// While the debug UI is hidden, Draw draws nothing.
 func (d *DebugUI) Draw(screen *ebiten.Image) {
-	d.ctx.draw(screen)
+	if !d.hidden {
+		d.ctx.draw(screen)
+	}
 	d.ctx.screenWidth, d.ctx.screenHeight = screen.Bounds().Dx(), screen.Bounds().Dy()
 }
+
+// SetFontFace sets the font face used for the debug UI.
+//
+// The metrics like the widget heights are adjusted to the line height of the font face.
+// If face is nil, the default font face is used.
+//
+// SetFontFace should not be called in the function passed to Update.
+func (d *DebugUI) SetFontFace(face text.Face) {
+	d.ctx.face = face
+	d.ctx.currentStyle = nil
+	clear(d.ctx.scaledFaces)
+}
+
+// SetBoldFontFace sets the font face used for bold text, e.g. in [Context.RichText].
+//
+// If face is nil, bold text is rendered with the regular font face drawn twice with a 1-pixel offset.
+// The line height of the bold font face should be the same as the regular font face.
+func (d *DebugUI) SetBoldFontFace(face text.Face) {
+	d.ctx.boldFace = face
+	clear(d.ctx.scaledFaces)
+}
+
+// SetVisible shows or hides the debug UI.
+//
+// While the debug UI is hidden, the debug UI is not drawn and doesn't capture input.
+// The state of the windows is kept while the debug UI is hidden.
+//
+// The debug UI is visible by default.
+func (d *DebugUI) SetVisible(visible bool) {
+	d.hidden = !visible
+	if d.hidden {
+		d.ctx.focus = widgetID{}
+	}
+}
+
+// IsVisible reports whether the debug UI is visible.
+func (d *DebugUI) IsVisible() bool {
+	return !d.hidden
+}
+
+// SetToggleShortcut sets the key chord to toggle the visibility of the debug UI, such as "F12" or "Ctrl+Shift+D".
+// See [Context.Shortcut] for the format of chord.
+//
+// If chord is empty, the visibility is not toggled by keys. There is no toggle key chord by default.
+//
+// The toggle key chord cannot be used by [Context.Shortcut], and Update returns an error for such a conflict.
+// The toggle key chord doesn't work while a text field has focus.
+//
+// SetToggleShortcut returns an error if chord is invalid.
+func (d *DebugUI) SetToggleShortcut(chord string) error {
+	if chord == "" {
+		d.toggleShortcut = shortcut{}
+		d.hasToggleShortcut = false
+		return nil
+	}
+	s, err := parseShortcut(chord)
+	if err != nil {
+		return err
+	}
+	d.toggleShortcut = s
+	d.hasToggleShortcut = true
+	return nil
+}
+
+// SetOpacity sets the opacity of the debug UI in the range [0, 1].
+//
+// The opacity is multiplied to the colors of all the rectangles, texts and icons of the debug UI,
+// so that the game behind the debug UI can be seen.
+// The opacity is not applied to the drawing by [Context.DrawOnlyWidget].
+//
+// The default opacity is 1.
+func (d *DebugUI) SetOpacity(opacity float64) {
+	d.ctx.opacityMinus1 = clamp(opacity, 0, 1) - 1
+}
+
+// Opacity returns the opacity of the debug UI.
+func (d *DebugUI) Opacity() float64 {
+	return d.ctx.opacity()
+}
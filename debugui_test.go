@@ -4,11 +4,20 @@
 package debugui_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"image"
+	"image/color"
+	"log/slog"
+	"math"
+	"slices"
 	"testing"
 
 	"github.com/ebitengine/debugui"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 func TestMultipleIDPartFromCallersInForLoop(t *testing.T) {
@@ -138,3 +147,2115 @@ func TestUnusedContainer(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestModal(t *testing.T) {
+	var d debugui.DebugUI
+	// Update must be called at the same place for every tick, as the window IDs depend on the caller.
+	for i, tc := range []struct {
+		open      bool
+		close     bool
+		wantFocus bool
+	}{
+		{},
+		{open: true},
+		{wantFocus: true},
+		{close: true},
+		{},
+	} {
+		state, err := d.Update(func(ctx *debugui.Context) error {
+			ctx.Window("Window", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {
+				modalID := ctx.Modal("Modal", func(layout debugui.ContainerLayout, modalID debugui.ModalID) {
+				})
+				if tc.open {
+					ctx.OpenModal(modalID)
+				}
+				if tc.close {
+					ctx.CloseModal(modalID)
+				}
+			})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.open || tc.close {
+			continue
+		}
+		if got, want := state&debugui.InputCapturingStateFocus != 0, tc.wantFocus; got != want {
+			t.Errorf("tick %d: focus: got: %v, want: %v", i, got, want)
+		}
+	}
+}
+
+func TestModalBlocksInput(t *testing.T) {
+	var buttonBounds image.Rectangle
+	var clicks int
+	var openModal bool
+	f := func(ctx *debugui.Context) error {
+		ctx.SetTooltipDelay(0)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").Tooltip("Tooltip").On(func() {
+				clicks++
+			})
+			buttonBounds = ctx.CurrentBounds()
+			modalID := ctx.Modal("Modal", func(layout debugui.ContainerLayout, modalID debugui.ModalID) {
+				ctx.Text("Content")
+			})
+			if openModal {
+				ctx.OpenModal(modalID)
+				openModal = false
+			}
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.d.SetScreenSize(640, 480)
+
+	u.update()
+	u.input.MoveTo(center(buttonBounds).X, center(buttonBounds).Y)
+	// The tooltip is shown at the second tick resting on the button,
+	// and the auto-sized tooltip window has its size from the third tick.
+	u.updateTicks(3)
+	if !u.drawn("Tooltip") {
+		t.Fatalf("the tooltip must be shown without a modal window")
+	}
+	u.click(center(buttonBounds))
+	if got, want := clicks, 1; got != want {
+		t.Fatalf("clicks without a modal window: got: %d, want: %d", got, want)
+	}
+
+	// The window behind the modal window gets neither hover nor clicks.
+	// The modal window blocks the input from the tick after OpenModal.
+	openModal = true
+	u.updateTicks(2)
+	if u.drawn("Tooltip") {
+		t.Errorf("the tooltip of the window behind the modal window must not be shown")
+	}
+	u.click(center(buttonBounds))
+	if got, want := clicks, 1; got != want {
+		t.Errorf("clicks behind the modal window: got: %d, want: %d", got, want)
+	}
+}
+
+func TestTooltip(t *testing.T) {
+	var clicked bool
+	f := func(ctx *debugui.Context) error {
+		ctx.SetTooltipDelay(0)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").Tooltip("Tooltip").On(func() {
+				clicked = true
+			})
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+
+	u.input.MoveTo(190, 190)
+	u.update()
+	if got, want := u.d.ContainerCounter(), 1; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Rest on the button.
+	u.input.MoveTo(50, 30)
+	u.update()
+	if got, want := u.d.ContainerCounter(), 2; got != want {
+		t.Errorf("tooltip window: got: %v, want: %v", got, want)
+	}
+
+	// The chained EventHandler still handles the click.
+	u.input.Press()
+	u.update()
+	if !clicked {
+		t.Errorf("the button must be clicked")
+	}
+}
+
+func TestTooltipWithoutWidget(t *testing.T) {
+	var selected int
+	var buttonBounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.SetTooltipDelay(0)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button")
+			buttonBounds = ctx.CurrentBounds()
+			// A list box without items creates no widget, so the tooltip must not be attached to the button.
+			ctx.ListBox(&selected, nil).Tooltip("Tooltip")
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+
+	u.update()
+	u.input.MoveTo(center(buttonBounds).X, center(buttonBounds).Y)
+	u.updateTicks(3)
+	if u.drawn("Tooltip") {
+		t.Errorf("the tooltip must not be shown on the button")
+	}
+}
+
+// testUI is a DebugUI updated with f and the simulated input.
+type testUI struct {
+	t     *testing.T
+	d     debugui.DebugUI
+	input debugui.TestInput
+	f     func(ctx *debugui.Context) error
+}
+
+// newTestUI returns a testUI calling f at every tick.
+func newTestUI(t *testing.T, f func(ctx *debugui.Context) error) *testUI {
+	return &testUI{
+		t: t,
+		f: f,
+	}
+}
+
+// update calls Update with f for one tick.
+func (u *testUI) update() {
+	u.t.Helper()
+	if _, err := u.d.UpdateWithInput(&u.input, u.f); err != nil {
+		u.t.Fatal(err)
+	}
+}
+
+// updateTicks calls Update with f for n ticks.
+func (u *testUI) updateTicks(n int) {
+	u.t.Helper()
+	for range n {
+		u.update()
+	}
+}
+
+// click moves the cursor to pt, and presses and releases the left mouse button, updating for each step.
+func (u *testUI) click(pt image.Point) {
+	u.t.Helper()
+	for _, step := range []func(){
+		func() { u.input.MoveTo(pt.X, pt.Y) },
+		u.input.Press,
+		u.input.Release,
+	} {
+		step()
+		u.update()
+	}
+}
+
+// drag moves the cursor to from, presses the left mouse button, moves the cursor to to, and releases the button,
+// updating for each step.
+func (u *testUI) drag(from, to image.Point) {
+	u.t.Helper()
+	for _, step := range []func(){
+		func() { u.input.MoveTo(from.X, from.Y) },
+		u.input.Press,
+		func() { u.input.MoveTo(to.X, to.Y) },
+		u.input.Release,
+	} {
+		step()
+		u.update()
+	}
+}
+
+// pressKey presses and releases the key, updating for each step.
+func (u *testUI) pressKey(key ebiten.Key) {
+	u.t.Helper()
+	u.input.PressKey(key)
+	u.update()
+	u.input.ReleaseKey(key)
+	u.update()
+}
+
+// drawn reports whether str is drawn in the last update.
+func (u *testUI) drawn(str string) bool {
+	_, ok := u.d.TextBounds(str)
+	return ok
+}
+
+// textBounds returns the bounds of str drawn in the last update.
+func (u *testUI) textBounds(str string) image.Rectangle {
+	u.t.Helper()
+	b, ok := u.d.TextBounds(str)
+	if !ok {
+		u.t.Fatalf("%q is not drawn", str)
+	}
+	return b
+}
+
+// center returns the center point of r.
+func center(r image.Rectangle) image.Point {
+	return r.Min.Add(r.Size().Div(2))
+}
+
+func TestRadioGroup(t *testing.T) {
+	labels := []string{"A", "B", "C"}
+	for _, tc := range []struct {
+		name   string
+		widget func(ctx *debugui.Context, value *int, labels []string) debugui.WidgetEventHandler
+		// want is the index at the center of the bounds of the last widget.
+		want int
+	}{
+		// The last widget of a radio group is the last radio button.
+		{name: "RadioGroup", widget: (*debugui.Context).RadioGroup, want: 2},
+		{name: "SegmentedControl", widget: (*debugui.Context).SegmentedControl, want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var value, events int
+			var bounds image.Rectangle
+			u := newTestUI(t, func(ctx *debugui.Context) error {
+				ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+					ctx.SetGridLayout([]int{-1}, nil)
+					tc.widget(ctx, &value, labels).On(func() {
+						events++
+					})
+					bounds = ctx.CurrentBounds()
+				})
+				return nil
+			})
+			u.update()
+
+			u.click(center(bounds))
+			if got, want := value, tc.want; got != want {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+			u.click(center(bounds))
+			if got, want := events, 1; got != want {
+				t.Errorf("clicking the selected option must not change the value: got: %v events, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckbox(t *testing.T) {
+	var checked bool
+	var bounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.Checkbox(&checked, "Check")
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	checkIcons := func() int {
+		var n int
+		for _, r := range u.d.IconBounds() {
+			if r.In(bounds) {
+				n++
+			}
+		}
+		return n
+	}
+
+	u.update()
+	if got, want := checkIcons(), 0; got != want {
+		t.Errorf("check icons: got: %v, want: %v", got, want)
+	}
+
+	u.click(center(bounds))
+	if !checked {
+		t.Fatalf("the checkbox must be checked")
+	}
+	if got, want := checkIcons(), 1; got != want {
+		t.Errorf("check icons: got: %v, want: %v", got, want)
+	}
+
+	// The box is at the left of the label.
+	var box image.Rectangle
+	for _, r := range u.d.IconBounds() {
+		if r.In(bounds) {
+			box = r
+		}
+	}
+	if got, want := box.Min.X, bounds.Min.X; got != want {
+		t.Errorf("box: got: %v, want: %v", got, want)
+	}
+	label, ok := u.d.TextBounds("Check")
+	if !ok {
+		t.Fatalf("the label must be drawn")
+	}
+	if label.Min.X < box.Max.X {
+		t.Errorf("label %v must be at the right of the box %v", label, box)
+	}
+}
+
+func TestToggleButtonAndSwitch(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		widget func(ctx *debugui.Context, state *bool, label string) debugui.WidgetEventHandler
+	}{
+		{name: "ToggleButton", widget: (*debugui.Context).ToggleButton},
+		{name: "Switch", widget: (*debugui.Context).Switch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var state bool
+			var events int
+			var bounds image.Rectangle
+			u := newTestUI(t, func(ctx *debugui.Context) error {
+				ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+					tc.widget(ctx, &state, "Label").On(func() {
+						events++
+					})
+					bounds = ctx.CurrentBounds()
+				})
+				return nil
+			})
+			u.update()
+
+			u.click(center(bounds))
+			if !state {
+				t.Errorf("the state must be on")
+			}
+			u.click(center(bounds))
+			if state {
+				t.Errorf("the state must be off")
+			}
+			if got, want := events, 2; got != want {
+				t.Errorf("got: %v events, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		fraction float64
+		// want is the width of the bar in 1/4 of the widget width.
+		want int
+	}{
+		{name: "zero", fraction: 0, want: 0},
+		{name: "half", fraction: 0.5, want: 2},
+		{name: "full", fraction: 1, want: 4},
+		{name: "negative", fraction: -1, want: 0},
+		{name: "over", fraction: 2, want: 4},
+		{name: "NaN", fraction: math.NaN(), want: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var bounds image.Rectangle
+			u := newTestUI(t, func(ctx *debugui.Context) error {
+				ctx.Window("Window", image.Rect(100, 100, 300, 300), func(layout debugui.ContainerLayout) {
+					ctx.ProgressBar(tc.fraction, "")
+					bounds = ctx.CurrentBounds()
+				})
+				return nil
+			})
+			u.update()
+			var want []image.Rectangle
+			if tc.want > 0 {
+				bar := bounds
+				bar.Max.X = bar.Min.X + bounds.Dx()*tc.want/4
+				want = append(want, bar)
+			}
+			if got := u.d.RectBounds(debugui.ColorButtonFocus); !slices.Equal(got, want) {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIndeterminateProgressBar(t *testing.T) {
+	var bounds image.Rectangle
+	u := newTestUI(t, func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(100, 100, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.IndeterminateProgressBar("")
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	})
+
+	bar := func() image.Rectangle {
+		t.Helper()
+		rects := u.d.RectBounds(debugui.ColorButtonFocus)
+		if len(rects) != 1 {
+			t.Fatalf("got %d bars, want 1", len(rects))
+		}
+		return rects[0]
+	}
+
+	// The layout is settled at the second tick.
+	u.updateTicks(2)
+	prev := bar()
+	if !prev.In(bounds) {
+		t.Errorf("the bar %v is out of the widget %v", prev, bounds)
+	}
+	// The bar moves to the right and then back to the left.
+	u.updateTicks(30)
+	b := bar()
+	if b.Min.X <= prev.Min.X {
+		t.Errorf("the bar must move to the right: got: %v, previous: %v", b, prev)
+	}
+	if got, want := b.Dx(), prev.Dx(); got != want {
+		t.Errorf("the bar width must be kept: got: %d, want: %d", got, want)
+	}
+	prev = b
+	u.updateTicks(60)
+	if b := bar(); b.Min.X >= prev.Min.X {
+		t.Errorf("the bar must move to the left: got: %v, previous: %v", b, prev)
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	u := newTestUI(t, func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(100, 100, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.Spinner()
+		})
+		return nil
+	})
+
+	// The head of the spinner is the only dot in the full text color.
+	head := func() image.Rectangle {
+		t.Helper()
+		rects := u.d.RectBounds(debugui.ColorText)
+		if len(rects) != 1 {
+			t.Fatalf("got %d heads, want 1", len(rects))
+		}
+		return rects[0]
+	}
+
+	// The layout is settled at the second tick.
+	u.updateTicks(2)
+	heads := []image.Rectangle{head()}
+	for range 7 {
+		// The head moves to the next dot every 6 ticks.
+		u.updateTicks(6)
+		heads = append(heads, head())
+	}
+	for i, h := range heads {
+		if slices.Contains(heads[:i], h) {
+			t.Errorf("the head %v at step %d is at a previous position", h, i)
+		}
+	}
+	// The head goes around after 8 dots.
+	u.updateTicks(6)
+	if got, want := head(), heads[0]; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestFilterOptions(t *testing.T) {
+	options := []string{"goblin", "Big Goblin", "orc", "gold", "lobster"}
+	testCases := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{0, 1, 2, 3, 4}},
+		{"gob", []int{0, 1}},
+		{"GO", []int{0, 3, 1}},
+		{"ol", []int{3, 0, 1}},
+		{"gbn", []int{0, 1}},
+		{"xyz", nil},
+	}
+	for _, tc := range testCases {
+		if got := debugui.FilterOptions(options, tc.query); !slices.Equal(got, tc.want) {
+			t.Errorf("FilterOptions(%q): got: %v, want: %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestComboBoxEscapeInModal(t *testing.T) {
+	options := []string{"A", "B", "C"}
+	var selected int
+	var comboBoxBounds image.Rectangle
+	openModal := true
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			modalID := ctx.Modal("Modal", func(layout debugui.ContainerLayout, modalID debugui.ModalID) {
+				ctx.ComboBox(&selected, options)
+				comboBoxBounds = ctx.CurrentBounds()
+			})
+			if openModal {
+				ctx.OpenModal(modalID)
+				openModal = false
+			}
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+
+	// OpenModal is called in the first tick, and the modal window is open from the next tick.
+	// The auto-sized modal window lags one tick behind its content, so it fits the title bar in the third tick,
+	// the combo box in the fourth tick, and the content is laid out in the final bounds in the fifth tick.
+	const modalLayoutTicks = 5
+	u.updateTicks(modalLayoutTicks)
+	if !u.drawn("A") {
+		t.Fatalf("the modal window must be open")
+	}
+	u.click(center(comboBoxBounds))
+	if !u.drawn("B") {
+		t.Fatalf("the list must be open")
+	}
+
+	// The first Escape closes only the list.
+	u.pressKey(ebiten.KeyEscape)
+	if u.drawn("B") {
+		t.Errorf("the list must be closed")
+	}
+	if !u.drawn("A") {
+		t.Errorf("the modal window must be open")
+	}
+
+	// The second Escape closes the modal window.
+	u.pressKey(ebiten.KeyEscape)
+	if u.drawn("A") {
+		t.Errorf("the modal window must be closed")
+	}
+}
+
+func TestVirtualList(t *testing.T) {
+	var d debugui.DebugUI
+	var contentSize image.Point
+	var called int
+	for range 2 {
+		called = 0
+		if _, err := d.Update(func(ctx *debugui.Context) error {
+			ctx.Window("Window", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {
+				contentSize = layout.ContentSize
+				ctx.VirtualList(1000, 20, func(index int) {
+					called++
+					ctx.Text("Row")
+				})
+			})
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if called == 0 || called >= 1000 {
+		t.Errorf("got: %d rows, want: only visible rows", called)
+	}
+	// The row height is 20, and the spacing is 4.
+	if got, want := contentSize.Y, 1000*24-4; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestTable(t *testing.T) {
+	columns := []debugui.TableColumn{
+		{Name: "Name", Width: 60},
+		{Name: "Action", Width: 60},
+	}
+	names := []string{"Row 0", "Row 1", "Row 2", "Row 3"}
+	buttons := []string{"Button 0", "Button 1", "Button 2", "Button 3"}
+	options := debugui.TableOptions{
+		Sort:        &debugui.TableSort{},
+		Selection:   &[]int{},
+		MultiSelect: true,
+	}
+	var events int
+	var buttonClicks int
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.SetGridLayout([]int{-1}, []int{-1})
+			ctx.Table(columns, len(names), &options, func(row, column int) {
+				switch column {
+				case 0:
+					ctx.Text(names[row])
+				case 1:
+					ctx.Button(buttons[row]).On(func() {
+						buttonClicks++
+					})
+				}
+			}).On(func() {
+				events++
+			})
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.update()
+
+	// Clicking a header sorts the column in ascending order, and clicking it again toggles the order.
+	for _, tc := range []struct {
+		column string
+		want   debugui.TableSort
+	}{
+		{column: "Action", want: debugui.TableSort{Column: 1}},
+		{column: "Action", want: debugui.TableSort{Column: 1, Descending: true}},
+		{column: "Name", want: debugui.TableSort{Column: 0}},
+	} {
+		u.click(center(u.textBounds(tc.column)))
+		if got := *options.Sort; got != tc.want {
+			t.Errorf("sort after clicking %q: got: %+v, want: %+v", tc.column, got, tc.want)
+		}
+	}
+	if got, want := events, 3; got != want {
+		t.Errorf("sort: got: %d events, want: %d", got, want)
+	}
+
+	// Dragging the right edge of a header resizes the column.
+	// The header text starts after the padding (5), and the columns are separated by the spacing (4).
+	actionX := u.textBounds("Action").Min.X
+	edge := image.Pt(actionX-5-4-1, center(u.textBounds("Action")).Y)
+	u.drag(edge, edge.Add(image.Pt(20, 0)))
+	if got, want := u.textBounds("Action").Min.X, actionX+20; got != want {
+		t.Errorf("resize: got: %d, want: %d", got, want)
+	}
+
+	// Clicking rows selects them.
+	events = 0
+	u.click(center(u.textBounds(names[0])))
+	u.input.PressKey(ebiten.KeyControl)
+	u.click(center(u.textBounds(names[2])))
+	u.input.ReleaseKey(ebiten.KeyControl)
+	if got, want := *options.Selection, []int{0, 2}; !slices.Equal(got, want) {
+		t.Errorf("Control+click: got: %v, want: %v", got, want)
+	}
+	u.input.PressKey(ebiten.KeyShift)
+	u.click(center(u.textBounds(names[3])))
+	u.input.ReleaseKey(ebiten.KeyShift)
+	// The range starts at the last clicked row.
+	if got, want := *options.Selection, []int{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("Shift+click: got: %v, want: %v", got, want)
+	}
+	if got, want := events, 3; got != want {
+		t.Errorf("selection: got: %d events, want: %d", got, want)
+	}
+
+	// Clicking a widget in a cell doesn't select the row.
+	u.click(center(u.textBounds(buttons[1])))
+	if got, want := buttonClicks, 1; got != want {
+		t.Errorf("button: got: %d clicks, want: %d", got, want)
+	}
+	if got, want := *options.Selection, []int{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("button: got: %v, want: %v", got, want)
+	}
+}
+
+func TestListBox(t *testing.T) {
+	items := []string{"Item 0", "Item 1", "Item 2", "Item 3"}
+	selected := -1
+	multi := make([]bool, len(items))
+	var multiEvents int
+	// The two list boxes are in different windows so that their items can be found by the texts.
+	var listBoxWindow bool
+	f := func(ctx *debugui.Context) error {
+		if listBoxWindow {
+			ctx.Window("ListBox", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+				ctx.SetGridLayout([]int{-1}, []int{-1})
+				ctx.ListBox(&selected, items)
+			})
+		} else {
+			ctx.Window("MultiSelectListBox", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+				ctx.SetGridLayout([]int{-1}, []int{-1})
+				ctx.MultiSelectListBox(multi, items).On(func() {
+					multiEvents++
+				})
+			})
+		}
+		return nil
+	}
+	u := newTestUI(t, f)
+	clickItem := func(index int) {
+		t.Helper()
+		u.click(center(u.textBounds(items[index])))
+	}
+
+	listBoxWindow = true
+	u.update()
+	clickItem(2)
+	if got, want := selected, 2; got != want {
+		t.Errorf("ListBox: got: %v, want: %v", got, want)
+	}
+
+	listBoxWindow = false
+	u.update()
+	clickItem(0)
+	u.input.PressKey(ebiten.KeyControl)
+	clickItem(2)
+	u.input.ReleaseKey(ebiten.KeyControl)
+	if got, want := multi, []bool{true, false, true, false}; !slices.Equal(got, want) {
+		t.Errorf("Control+click: got: %v, want: %v", got, want)
+	}
+	u.input.PressKey(ebiten.KeyShift)
+	clickItem(3)
+	u.input.ReleaseKey(ebiten.KeyShift)
+	// The range starts at the last clicked item.
+	if got, want := multi, []bool{false, false, true, true}; !slices.Equal(got, want) {
+		t.Errorf("Shift+click: got: %v, want: %v", got, want)
+	}
+	if got, want := multiEvents, 3; got != want {
+		t.Errorf("MultiSelectListBox: got: %v events, want: %v", got, want)
+	}
+}
+
+func TestDockLayout(t *testing.T) {
+	const layout = `{"ratio":0.25,"children":[{"windows":["Window1","Window2"],"active":1},{}]}`
+
+	// The windows are declared at the same call sites in every tick, as the windows are identified by their IDs.
+	window2 := true
+	windows := func(ctx *debugui.Context) {
+		ctx.Window("Window1", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {})
+		if window2 {
+			ctx.Window("Window2", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {})
+		}
+	}
+
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.SetDockingEnabled(true)
+		for _, invalid := range []string{
+			`{"ratio":0.5,"children":[{}]}`,
+			`{"ratio":0.5,"children":[{"windows":["Window1"]},{"windows":["Window1"]}]}`,
+		} {
+			if err := ctx.SetDockLayout([]byte(invalid)); err == nil {
+				t.Errorf("SetDockLayout(%s) returned nil, want error", invalid)
+			}
+		}
+		if err := ctx.SetDockLayout([]byte(layout)); err != nil {
+			t.Fatal(err)
+		}
+		windows(ctx)
+		got, err := ctx.DockLayout()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != layout {
+			t.Errorf("DockLayout(): got: %s, want: %s", got, layout)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A window that is no longer declared keeps its place, and another tab is activated.
+	window2 = false
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		windows(ctx)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		windows(ctx)
+		got, err := ctx.DockLayout()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"ratio":0.25,"children":[{"windows":["Window1","Window2"]},{}]}`; string(got) != want {
+			t.Errorf("DockLayout(): got: %s, want: %s", got, want)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDocking(t *testing.T) {
+	openB := true
+	openC := true
+	var boundsA image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.SetDockingEnabled(true)
+		ctx.Window("A", image.Rect(100, 100, 200, 200), func(layout debugui.ContainerLayout) {
+			boundsA = layout.Bounds
+		})
+		ctx.WindowWithOptions("B", image.Rect(250, 100, 350, 200), &debugui.WindowOptions{Open: &openB}, func(layout debugui.ContainerLayout) {})
+		ctx.WindowWithOptions("C", image.Rect(400, 100, 500, 200), &debugui.WindowOptions{Open: &openC}, func(layout debugui.ContainerLayout) {})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.d.SetScreenSize(640, 480)
+	dragTitle := func(title string, to image.Point) {
+		t.Helper()
+		u.drag(center(u.textBounds(title)), to)
+	}
+	checkLayout := func(want string) {
+		t.Helper()
+		if _, err := u.d.UpdateWithInput(&u.input, func(ctx *debugui.Context) error {
+			if err := f(ctx); err != nil {
+				return err
+			}
+			got, err := ctx.DockLayout()
+			if err != nil {
+				return err
+			}
+			if string(got) != want {
+				t.Errorf("DockLayout(): got: %s, want: %s", got, want)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	u.update()
+
+	// Dropping a window onto a screen edge docks the window at the edge.
+	dragTitle("A", image.Pt(5, 240))
+	checkLayout(`{"ratio":0.25,"children":[{"windows":["A"]},{}]}`)
+
+	// Dropping a window onto an edge of a docked window splits the region.
+	// The region of A is (0, 0)-(158, 480).
+	dragTitle("B", image.Pt(150, 240))
+	checkLayout(`{"ratio":0.25,"children":[{"ratio":0.5,"children":[{"windows":["A"]},{"windows":["B"]}]},{}]}`)
+
+	// Dropping a window onto the center of a docked window stacks the windows as tabs.
+	// The region of A is (0, 0)-(76, 480).
+	dragTitle("C", image.Pt(38, 240))
+	checkLayout(`{"ratio":0.25,"children":[{"ratio":0.5,"children":[{"windows":["A","C"],"active":1},{"windows":["B"]}]},{}]}`)
+
+	// The tabs are not under the close button of the active window.
+	for _, title := range []string{"A", "C"} {
+		b := u.textBounds(title)
+		for _, icon := range u.d.IconBounds() {
+			if b.Overlaps(icon) {
+				t.Errorf("the tab %s %v overlaps the icon %v", title, b, icon)
+			}
+		}
+	}
+
+	// A closed window keeps its place, and another tab is activated.
+	openC = false
+	u.update()
+	checkLayout(`{"ratio":0.25,"children":[{"ratio":0.5,"children":[{"windows":["A","C"]},{"windows":["B"]}]},{}]}`)
+	if u.drawn("C") {
+		t.Errorf("the closed window C must not have a tab")
+	}
+	if !u.drawn("A") {
+		t.Errorf("the window A must be shown")
+	}
+
+	// A reopened window is shown at the same place as a tab.
+	openC = true
+	u.updateTicks(2)
+	checkLayout(`{"ratio":0.25,"children":[{"ratio":0.5,"children":[{"windows":["A","C"]},{"windows":["B"]}]},{}]}`)
+	if !u.drawn("C") {
+		t.Errorf("the reopened window C must have a tab")
+	}
+
+	// The region of a closed window is collapsed, and the neighboring region takes the space.
+	openB = false
+	u.updateTicks(2)
+	if got, want := boundsA, image.Rect(0, 0, 158, 480); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// A reopened window is shown in its region again.
+	// The windows declared before the reopened window are laid out in the next tick.
+	openB = true
+	u.updateTicks(2)
+	if got, want := boundsA, image.Rect(0, 0, 76, 480); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	checkLayout(`{"ratio":0.25,"children":[{"ratio":0.5,"children":[{"windows":["A","C"]},{"windows":["B"]}]},{}]}`)
+}
+
+func TestDockingSameTitle(t *testing.T) {
+	bounds := make([]image.Rectangle, 2)
+	f := func(ctx *debugui.Context) error {
+		ctx.SetDockingEnabled(true)
+		for i := range bounds {
+			ctx.IDScope(fmt.Sprint(i), func() {
+				ctx.Window("Window", image.Rect(100+200*i, 100, 200+200*i, 200), func(layout debugui.ContainerLayout) {
+					bounds[i] = layout.Bounds
+				})
+			})
+		}
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.d.SetScreenSize(640, 480)
+	u.update()
+
+	// Only the dragged window is docked, even though the windows have the same title.
+	u.drag(image.Pt(150, 112), image.Pt(5, 240))
+	// The window is docked when the button is released, and laid out in the dock region in the next tick.
+	u.update()
+	if got, want := bounds[0], image.Rect(0, 0, 158, 480); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := bounds[1], image.Rect(300, 100, 400, 200); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestSnapWindowBounds(t *testing.T) {
+	// The window A is at (100, 100)-(200, 200), and the window B is at (300, 100)-(400, 200).
+	// The snap threshold is 8, which is twice the spacing.
+	for _, tc := range []struct {
+		name  string
+		delta image.Point
+		want  image.Rectangle
+	}{
+		{name: "screen edge within threshold", delta: image.Pt(-93, 0), want: image.Rect(0, 100, 100, 200)},
+		{name: "screen edge at threshold", delta: image.Pt(-92, 0), want: image.Rect(0, 100, 100, 200)},
+		{name: "screen edge beyond threshold", delta: image.Pt(-91, 0), want: image.Rect(9, 100, 109, 200)},
+		{name: "top screen edge", delta: image.Pt(0, -95), want: image.Rect(100, 0, 200, 100)},
+		{name: "window edge within threshold", delta: image.Pt(95, 0), want: image.Rect(200, 100, 300, 200)},
+		{name: "window edge beyond threshold", delta: image.Pt(90, 0), want: image.Rect(190, 100, 290, 200)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var bounds image.Rectangle
+			u := newTestUI(t, func(ctx *debugui.Context) error {
+				ctx.Window("A", image.Rect(100, 100, 200, 200), func(layout debugui.ContainerLayout) {
+					bounds = layout.Bounds
+				})
+				ctx.Window("B", image.Rect(300, 100, 400, 200), func(layout debugui.ContainerLayout) {})
+				return nil
+			})
+			u.d.SetScreenSize(640, 480)
+			u.update()
+			from := center(u.textBounds("A"))
+			u.drag(from, from.Add(tc.delta))
+			if bounds != tc.want {
+				t.Errorf("got: %v, want: %v", bounds, tc.want)
+			}
+		})
+	}
+}
+
+func TestArrangeWindows(t *testing.T) {
+	// The screen is (0, 0)-(640, 480), and the windows are arranged in the screen inset by the spacing (4).
+	for _, tc := range []struct {
+		name    string
+		size    image.Point
+		arrange func(ctx *debugui.Context)
+		want    []image.Rectangle
+	}{
+		{
+			name:    "tile",
+			size:    image.Pt(100, 100),
+			arrange: (*debugui.Context).TileWindows,
+			want: []image.Rectangle{
+				image.Rect(4, 4, 318, 238),
+				image.Rect(322, 4, 636, 238),
+				image.Rect(4, 242, 318, 476),
+			},
+		},
+		{
+			name:    "cascade",
+			size:    image.Pt(100, 100),
+			arrange: (*debugui.Context).CascadeWindows,
+			want: []image.Rectangle{
+				image.Rect(4, 4, 104, 104),
+				image.Rect(28, 28, 128, 128),
+				image.Rect(52, 52, 152, 152),
+			},
+		},
+		{
+			// A window exceeding the screen starts from the top-left corner again.
+			name:    "cascade wrap",
+			size:    image.Pt(200, 440),
+			arrange: (*debugui.Context).CascadeWindows,
+			want: []image.Rectangle{
+				image.Rect(4, 4, 204, 444),
+				image.Rect(28, 28, 228, 468),
+				image.Rect(4, 4, 204, 444),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var d debugui.DebugUI
+			d.SetScreenSize(640, 480)
+			titles := []string{"A", "B", "C"}
+			bounds := make([]image.Rectangle, len(titles))
+			for i := range 3 {
+				if _, err := d.Update(func(ctx *debugui.Context) error {
+					if i == 1 {
+						tc.arrange(ctx)
+					}
+					for j, title := range titles {
+						ctx.IDScope(title, func() {
+							ctx.Window(title, image.Rectangle{Max: tc.size}.Add(image.Pt(50*j, 50*j)), func(layout debugui.ContainerLayout) {
+								bounds[j] = layout.Bounds
+							})
+						})
+					}
+					return nil
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !slices.Equal(bounds, tc.want) {
+				t.Errorf("got: %v, want: %v", bounds, tc.want)
+			}
+		})
+	}
+}
+
+func TestWindowID(t *testing.T) {
+	var d debugui.DebugUI
+	var called bool
+	// control is called after the window is declared.
+	var control func(ctx *debugui.Context, windowID debugui.WindowID)
+	update := func() {
+		called = false
+		if _, err := d.Update(func(ctx *debugui.Context) error {
+			windowID := ctx.Window("Window", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {
+				called = true
+			})
+			if control != nil {
+				control(ctx, windowID)
+				control = nil
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	update()
+	if !called {
+		t.Errorf("the window content is not called")
+	}
+	control = func(ctx *debugui.Context, windowID debugui.WindowID) {
+		if !ctx.IsWindowOpen(windowID) {
+			t.Errorf("IsWindowOpen() returned false, want true")
+		}
+		ctx.CloseWindow(windowID)
+	}
+	update()
+	update()
+	if called {
+		t.Errorf("the content of the closed window is called")
+	}
+	control = func(ctx *debugui.Context, windowID debugui.WindowID) {
+		if ctx.IsWindowOpen(windowID) {
+			t.Errorf("IsWindowOpen() returned true, want false")
+		}
+		ctx.OpenWindow(windowID)
+	}
+	update()
+	update()
+	if !called {
+		t.Errorf("the content of the reopened window is not called")
+	}
+
+	// Controlling a window doesn't keep the window that is no longer declared.
+	var windowID debugui.WindowID
+	control = func(ctx *debugui.Context, id debugui.WindowID) {
+		windowID = id
+	}
+	update()
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.SetWindowBounds(windowID, image.Rect(0, 0, 100, 100))
+		ctx.SetWindowCollapsed(windowID, true)
+		ctx.OpenWindow(windowID)
+		ctx.CloseWindow(windowID)
+		ctx.BringWindowToFront(windowID)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.ContainerCounter(), 0; got != want {
+		t.Errorf("ContainerCounter(): got: %d, want: %d", got, want)
+	}
+
+	// Controlling a window that doesn't exist doesn't create a container.
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		var unknown debugui.WindowID
+		ctx.SetWindowBounds(unknown, image.Rect(0, 0, 100, 100))
+		ctx.SetWindowCollapsed(unknown, true)
+		ctx.OpenWindow(unknown)
+		ctx.CloseWindow(unknown)
+		ctx.BringWindowToFront(unknown)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.ContainerCounter(), 0; got != want {
+		t.Errorf("ContainerCounter(): got: %d, want: %d", got, want)
+	}
+}
+
+func TestWindowOpen(t *testing.T) {
+	open := true
+	var called bool
+	var bounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		called = false
+		ctx.WindowWithOptions("Window", image.Rect(0, 0, 100, 100), &debugui.WindowOptions{Open: &open}, func(layout debugui.ContainerLayout) {
+			called = true
+			bounds = layout.Bounds
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.update()
+	if !called {
+		t.Fatalf("the content of the open window is not called")
+	}
+
+	// The close button is at the right end of the title bar (24 pixels high).
+	u.click(image.Pt(bounds.Max.X-12, bounds.Min.Y+12))
+	if open {
+		t.Errorf("open: got: true, want: false")
+	}
+	if called {
+		t.Errorf("the content of the closed window is called")
+	}
+
+	open = true
+	u.update()
+	if !called {
+		t.Errorf("the content of the reopened window is not called")
+	}
+}
+
+func TestWindowOptions(t *testing.T) {
+	// The window is at (100, 100)-(300, 300), and the content is 150x150.
+	for _, tc := range []struct {
+		name          string
+		options       *debugui.WindowOptions
+		wantTitle     bool
+		wantAutoSize  bool
+		wantResizable bool
+	}{
+		{name: "nil", options: nil, wantTitle: true, wantResizable: true},
+		{name: "NoTitle", options: &debugui.WindowOptions{NoTitle: true}, wantTitle: false, wantResizable: true},
+		{name: "NoResize", options: &debugui.WindowOptions{NoResize: true}, wantTitle: true, wantResizable: false},
+		// NoScroll is specified so that the size is not affected by the scroll bars shown before the window fits the content.
+		{name: "AutoSize", options: &debugui.WindowOptions{AutoSize: true, NoScroll: true}, wantTitle: true, wantAutoSize: true, wantResizable: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var layout debugui.ContainerLayout
+			u := newTestUI(t, func(ctx *debugui.Context) error {
+				ctx.WindowWithOptions("Window", image.Rect(100, 100, 300, 300), tc.options, func(l debugui.ContainerLayout) {
+					layout = l
+					ctx.SetGridLayout([]int{150}, []int{150})
+					ctx.Text("Content")
+				})
+				return nil
+			})
+			u.d.SetScreenSize(640, 480)
+			// An auto-sized window is drawn with the size of the previous tick.
+			u.updateTicks(4)
+
+			if title := u.drawn("Window"); title != tc.wantTitle {
+				t.Errorf("title: got: %v, want: %v", title, tc.wantTitle)
+			}
+			if got, want := layout.BodyBounds.Min.Y > layout.Bounds.Min.Y, tc.wantTitle; got != want {
+				t.Errorf("body below the title bar: got: %v, want: %v", got, want)
+			}
+			if got, want := layout.Bounds.Dx() < 200, tc.wantAutoSize; got != want {
+				t.Errorf("auto size: got: %v (bounds: %v), want: %v", got, layout.Bounds, want)
+			}
+
+			// Drag the bottom-right corner.
+			size := layout.Bounds.Size()
+			corner := layout.Bounds.Max.Sub(image.Pt(5, 5))
+			u.drag(corner, corner.Add(image.Pt(20, 20)))
+			// An auto-sized window is resized to fit the content again.
+			if got, want := layout.Bounds.Size() != size, tc.wantResizable && !tc.wantAutoSize; got != want {
+				t.Errorf("resized: got: %v (size: %v -> %v), want: %v", got, size, layout.Bounds.Size(), want)
+			}
+		})
+	}
+}
+
+func TestConstrainWindowBounds(t *testing.T) {
+	// The default minimum size is 96x64.
+	for _, tc := range []struct {
+		name        string
+		screen      image.Point
+		bounds      image.Rectangle
+		edges       debugui.ResizeEdge
+		minSize     image.Point
+		maxSize     image.Point
+		aspectRatio float64
+		want        image.Rectangle
+	}{
+		{
+			name:   "default MinSize",
+			bounds: image.Rect(0, 0, 50, 30),
+			edges:  debugui.ResizeEdgeRight | debugui.ResizeEdgeBottom,
+			want:   image.Rect(0, 0, 96, 64),
+		},
+		{
+			name:    "MinSize",
+			bounds:  image.Rect(0, 0, 100, 80),
+			edges:   debugui.ResizeEdgeRight | debugui.ResizeEdgeBottom,
+			minSize: image.Pt(120, 100),
+			want:    image.Rect(0, 0, 120, 100),
+		},
+		{
+			name:    "MaxSize",
+			bounds:  image.Rect(0, 0, 200, 200),
+			edges:   debugui.ResizeEdgeRight | debugui.ResizeEdgeBottom,
+			maxSize: image.Pt(150, 120),
+			want:    image.Rect(0, 0, 150, 120),
+		},
+		{
+			name:   "left edge keeps the right edge",
+			bounds: image.Rect(150, 0, 200, 100),
+			edges:  debugui.ResizeEdgeLeft,
+			want:   image.Rect(104, 0, 200, 100),
+		},
+		{
+			name:   "top edge keeps the bottom edge",
+			bounds: image.Rect(0, 150, 100, 200),
+			edges:  debugui.ResizeEdgeTop,
+			want:   image.Rect(0, 136, 100, 200),
+		},
+		{
+			name:        "AspectRatio by a horizontal edge",
+			bounds:      image.Rect(0, 0, 200, 150),
+			edges:       debugui.ResizeEdgeRight,
+			aspectRatio: 2,
+			want:        image.Rect(0, 0, 200, 100),
+		},
+		{
+			name:        "AspectRatio by a vertical edge",
+			bounds:      image.Rect(0, 0, 200, 150),
+			edges:       debugui.ResizeEdgeBottom,
+			aspectRatio: 2,
+			want:        image.Rect(0, 0, 300, 150),
+		},
+		{
+			name:        "AspectRatio by the top-left corner",
+			bounds:      image.Rect(0, 0, 200, 150),
+			edges:       debugui.ResizeEdgeLeft | debugui.ResizeEdgeTop,
+			aspectRatio: 2,
+			want:        image.Rect(0, 50, 200, 150),
+		},
+		{
+			name:   "screen",
+			screen: image.Pt(640, 480),
+			bounds: image.Rect(500, 0, 700, 100),
+			edges:  debugui.ResizeEdgeRight,
+			want:   image.Rect(500, 0, 640, 100),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var d debugui.DebugUI
+			d.SetScreenSize(tc.screen.X, tc.screen.Y)
+			if got := d.ConstrainWindowBounds(tc.bounds, tc.edges, tc.minSize, tc.maxSize, tc.aspectRatio); got != tc.want {
+				t.Errorf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResizeWindow(t *testing.T) {
+	open := true
+	var bounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.WindowWithOptions("Window", image.Rect(100, 100, 300, 300), &debugui.WindowOptions{Open: &open}, func(layout debugui.ContainerLayout) {
+			bounds = layout.Bounds
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.d.SetScreenSize(640, 480)
+	u.update()
+
+	// The handle at the left edge is outside the window, and the right edge is kept.
+	u.drag(image.Pt(98, 200), image.Pt(78, 200))
+	if got, want := bounds, image.Rect(80, 100, 300, 300); got != want {
+		t.Errorf("left edge: got: %v, want: %v", got, want)
+	}
+
+	// The handle at the top edge resizes the right edge too near the top-right corner.
+	u.drag(image.Pt(299, 98), image.Pt(309, 88))
+	if got, want := bounds, image.Rect(80, 90, 310, 300); got != want {
+		t.Errorf("top-right corner: got: %v, want: %v", got, want)
+	}
+
+	// The top-right corner of the window is the close button, not a resize handle.
+	u.click(image.Pt(bounds.Max.X-1, bounds.Min.Y+1))
+	if open {
+		t.Errorf("the close button is not clicked")
+	}
+}
+
+func TestResizeHandleOutsideWindow(t *testing.T) {
+	var backID, frontID debugui.WindowID
+	var backBounds image.Rectangle
+	var frontHovered bool
+	f := func(ctx *debugui.Context) error {
+		backID = ctx.Window("Back", image.Rect(100, 100, 300, 300), func(layout debugui.ContainerLayout) {
+			backBounds = layout.Bounds
+		})
+		frontID = ctx.Window("Front", image.Rect(200, 200, 400, 400), func(layout debugui.ContainerLayout) {})
+		frontHovered = ctx.IsWindowHovered(frontID)
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.d.SetScreenSize(640, 480)
+	u.update()
+
+	// The resize handle outside the window doesn't hover the window.
+	u.input.MoveTo(98, 150)
+	state, err := u.d.UpdateWithInput(&u.input, func(ctx *debugui.Context) error {
+		if err := f(ctx); err != nil {
+			return err
+		}
+		if ctx.IsWindowHovered(backID) {
+			t.Errorf("the window must not be hovered by its resize handle")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != 0 {
+		t.Errorf("InputCapturingState: got: %v, want: 0", state)
+	}
+
+	// A click on the resize handle doesn't bring the window to front.
+	u.click(image.Pt(98, 150))
+	u.input.MoveTo(250, 250)
+	u.update()
+	if !frontHovered {
+		t.Errorf("the front window must be kept in front")
+	}
+
+	// The handle still resizes the window.
+	u.drag(image.Pt(98, 150), image.Pt(88, 150))
+	if got, want := backBounds, image.Rect(90, 100, 300, 300); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestWindowOptionsRemoved(t *testing.T) {
+	options := &debugui.WindowOptions{
+		MaxSize: image.Pt(200, 200),
+	}
+	var bounds image.Rectangle
+	u := newTestUI(t, func(ctx *debugui.Context) error {
+		ctx.WindowWithOptions("Window", image.Rect(100, 100, 300, 300), options, func(layout debugui.ContainerLayout) {
+			bounds = layout.Bounds
+		})
+		return nil
+	})
+	u.d.SetScreenSize(640, 480)
+	u.update()
+
+	corner := image.Pt(295, 295)
+	u.drag(corner, corner.Add(image.Pt(20, 20)))
+	if got, want := bounds, image.Rect(100, 100, 300, 300); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The size limit is removed with the options.
+	options = nil
+	u.drag(corner, corner.Add(image.Pt(20, 20)))
+	if got, want := bounds, image.Rect(100, 100, 320, 320); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestScaleRect(t *testing.T) {
+	for _, tc := range []struct {
+		r     image.Rectangle
+		scale float64
+		want  image.Rectangle
+	}{
+		{r: image.Rect(0, 0, 3, 3), scale: 1, want: image.Rect(0, 0, 3, 3)},
+		{r: image.Rect(0, 0, 3, 3), scale: 2, want: image.Rect(0, 0, 6, 6)},
+		// The adjacent rectangles share the rounded edge without a gap.
+		{r: image.Rect(0, 0, 3, 3), scale: 1.5, want: image.Rect(0, 0, 5, 5)},
+		{r: image.Rect(3, 0, 6, 3), scale: 1.5, want: image.Rect(5, 0, 9, 5)},
+		{r: image.Rect(1, 1, 2, 2), scale: 1.25, want: image.Rect(1, 1, 3, 3)},
+	} {
+		if got := debugui.ScaleRect(tc.r, tc.scale); got != tc.want {
+			t.Errorf("ScaleRect(%v, %v): got: %v, want: %v", tc.r, tc.scale, got, tc.want)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	for _, tc := range []struct {
+		set        func(ctx *debugui.Context)
+		wantScale  int
+		wantScaleF float64
+	}{
+		{set: func(ctx *debugui.Context) { ctx.SetScale(2) }, wantScale: 2, wantScaleF: 2},
+		{set: func(ctx *debugui.Context) { ctx.SetScaleF(1.5) }, wantScale: 1, wantScaleF: 1.5},
+		{set: func(ctx *debugui.Context) { ctx.SetScaleF(2.5) }, wantScale: 2, wantScaleF: 2.5},
+		{set: func(ctx *debugui.Context) { ctx.SetScaleF(0.5) }, wantScale: 1, wantScaleF: 0.5},
+	} {
+		var d debugui.DebugUI
+		if _, err := d.Update(func(ctx *debugui.Context) error {
+			tc.set(ctx)
+			if got, want := ctx.Scale(), tc.wantScale; got != want {
+				t.Errorf("Scale(): got: %d, want: %d", got, want)
+			}
+			if got, want := ctx.ScaleF(), tc.wantScaleF; got != want {
+				t.Errorf("ScaleF(): got: %v, want: %v", got, want)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFractionalScaleHitTest(t *testing.T) {
+	const scale = 1.5
+	var bounds image.Rectangle
+	var clicked int
+	f := func(ctx *debugui.Context) error {
+		ctx.SetScaleF(scale)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").On(func() {
+				clicked++
+			})
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.updateTicks(2)
+
+	// The cursor position is in the screen pixels, and the bounds are in the UI coordinate.
+	y := int(float64(bounds.Min.Y+bounds.Max.Y) / 2 * scale)
+	right := int(math.Ceil(float64(bounds.Max.X) * scale))
+	u.click(image.Pt(right-1, y))
+	if got, want := clicked, 1; got != want {
+		t.Errorf("inside the right edge: got: %d clicks, want: %d", got, want)
+	}
+	u.click(image.Pt(right, y))
+	if got, want := clicked, 1; got != want {
+		t.Errorf("outside the right edge: got: %d clicks, want: %d", got, want)
+	}
+}
+
+func newTestFontFace(t *testing.T, size float64) *text.GoTextFace {
+	t.Helper()
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &text.GoTextFace{
+		Source: src,
+		Size:   size,
+	}
+}
+
+func TestFontFace(t *testing.T) {
+	const str = "Hello"
+	var bounds image.Rectangle
+	u := newTestUI(t, func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.Button(str)
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	})
+	// The layout is settled at the second tick.
+	u.updateTicks(2)
+	defaultWidth, defaultLineHeight, defaultBounds := u.d.TextWidth(str), u.d.LineHeight(), bounds
+
+	face := newTestFontFace(t, 32)
+	u.d.SetFontFace(face)
+	u.update()
+	if got, want := u.d.TextWidth(str), int(text.Advance(str, face)); got != want {
+		t.Errorf("TextWidth(): got: %d, want: %d", got, want)
+	}
+	if got := u.d.LineHeight(); got <= defaultLineHeight {
+		t.Errorf("LineHeight(): got: %d, want: > %d", got, defaultLineHeight)
+	}
+	// The widget height is adjusted to the line height.
+	if got := bounds.Dy(); got < u.d.LineHeight() {
+		t.Errorf("button height: got: %d, want: >= %d", got, u.d.LineHeight())
+	}
+
+	// The bold font face affects only the bold text.
+	textWidth := u.d.TextWidth(str)
+	if got, want := u.d.BoldTextWidth(str), textWidth+1; got != want {
+		t.Errorf("BoldTextWidth() with faux bold: got: %d, want: %d", got, want)
+	}
+	boldFace := newTestFontFace(t, 16)
+	u.d.SetBoldFontFace(boldFace)
+	if got, want := u.d.BoldTextWidth(str), int(text.Advance(str, boldFace)); got != want {
+		t.Errorf("BoldTextWidth(): got: %d, want: %d", got, want)
+	}
+	if got, want := u.d.TextWidth(str), textWidth; got != want {
+		t.Errorf("TextWidth(): got: %d, want: %d", got, want)
+	}
+
+	u.d.SetFontFace(nil)
+	u.d.SetBoldFontFace(nil)
+	u.update()
+	if got, want := u.d.TextWidth(str), defaultWidth; got != want {
+		t.Errorf("TextWidth(): got: %d, want: %d", got, want)
+	}
+	if got, want := u.d.LineHeight(), defaultLineHeight; got != want {
+		t.Errorf("LineHeight(): got: %d, want: %d", got, want)
+	}
+	if got, want := bounds, defaultBounds; got != want {
+		t.Errorf("button bounds: got: %v, want: %v", got, want)
+	}
+}
+
+func TestScaledFontFace(t *testing.T) {
+	for _, bold := range []bool{false, true} {
+		t.Run(fmt.Sprintf("bold=%t", bold), func(t *testing.T) {
+			var d debugui.DebugUI
+			setFace := d.SetFontFace
+			if bold {
+				setFace = d.SetBoldFontFace
+			}
+			size := func(face text.Face) float64 {
+				t.Helper()
+				f, ok := face.(*text.GoTextFace)
+				if !ok {
+					t.Fatalf("the scaled face must be a GoTextFace: %T", face)
+				}
+				return f.Size
+			}
+
+			face := newTestFontFace(t, 16)
+			setFace(face)
+			scaled := d.ScaledFontFace(face, 2)
+			if got, want := size(scaled), 32.0; got != want {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+			if d.ScaledFontFace(face, 2) != scaled {
+				t.Errorf("the scaled face must be cached")
+			}
+
+			// Setting the face again invalidates the cached scaled faces, even though the face is the same pointer.
+			face.Size = 20
+			setFace(face)
+			if got, want := size(d.ScaledFontFace(face, 2)), 40.0; got != want {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRichTextPlain(t *testing.T) {
+	testCases := []struct {
+		markup string
+		want   string
+	}{
+		{"hello", "hello"},
+		{"a [b]b[/b] [color=red]c[/color]", "a b c"},
+		{"[color=#ff000080]ERROR[/color]: failed", "ERROR: failed"},
+		{"[[b] is bold", "[b] is bold"},
+		{"[unknown]tag", "[unknown]tag"},
+		{"[color=nocolor]x[/color]", "[color=nocolor]x[/color]"},
+		{"[icon=check] done", "\ufffc done"},
+		{"unclosed [b", "unclosed [b"},
+	}
+	for _, tc := range testCases {
+		if got := debugui.RichTextPlain(tc.markup); got != tc.want {
+			t.Errorf("RichTextPlain(%q): got: %q, want: %q", tc.markup, got, tc.want)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	var d debugui.DebugUI
+	const str = "Hello world"
+	for _, tc := range []struct {
+		width         int
+		want          string
+		wantTruncated bool
+	}{
+		{width: d.TextWidth(str), want: str},
+		{width: d.TextWidth(str) - 1, want: "Hello wor…", wantTruncated: true},
+		{width: d.TextWidth("Hel…"), want: "Hel…", wantTruncated: true},
+		{width: d.TextWidth("Hell…") - 1, want: "Hel…", wantTruncated: true},
+		// The space before the ellipsis is removed.
+		{width: d.TextWidth("Hello …"), want: "Hello…", wantTruncated: true},
+		{width: d.TextWidth("…"), want: "…", wantTruncated: true},
+		{width: 0, want: "…", wantTruncated: true},
+	} {
+		got, truncated := d.TruncateText(str, tc.width)
+		if got != tc.want || truncated != tc.wantTruncated {
+			t.Errorf("TruncateText(%q, %d): got: %q, %v, want: %q, %v", str, tc.width, got, truncated, tc.want, tc.wantTruncated)
+		}
+	}
+
+	// The truncated text fits in the width unless the width is narrower than the ellipsis.
+	for width := d.TextWidth("…"); width <= d.TextWidth(str); width++ {
+		got, _ := d.TruncateText(str, width)
+		if w := d.TextWidth(got); w > width {
+			t.Errorf("TruncateText(%q, %d): got: %q (width: %d), want: the text fitting in the width", str, width, got, w)
+		}
+	}
+}
+
+func TestVisualRuns(t *testing.T) {
+	testCases := []struct {
+		str  string
+		want []string
+	}{
+		{"hello", []string{"hello"}},
+		{"abc שלום def", []string{"abc ", "שלום", " def"}},
+		{"שלום abc 123 עולם", []string{" עולם", "abc 123", "שלום "}},
+		// The number between the right-to-left words is at a deeper level than the words.
+		{"abc שלום 123 עולם", []string{"abc ", " עולם", "123", "שלום "}},
+		{"abc 123 שלום", []string{"abc 123 ", "שלום"}},
+		{"שלום 1.5 עולם", []string{" עולם", "1.5", "שלום "}},
+	}
+	for _, tc := range testCases {
+		if got := debugui.VisualRuns(tc.str); !slices.Equal(got, tc.want) {
+			t.Errorf("VisualRuns(%q): got: %q, want: %q", tc.str, got, tc.want)
+		}
+	}
+}
+
+func TestMoveCaret(t *testing.T) {
+	var d debugui.DebugUI
+	// Each Hebrew letter is 2 bytes in UTF-8.
+	testCases := []struct {
+		str    string
+		offset int
+		dir    int
+		want   int
+	}{
+		{"abc", 1, -1, 0},
+		{"abc", 1, 1, 2},
+		{"abc", 0, -1, 0},
+		{"abc", 3, 1, 3},
+		// The logical end of "abc שלום" is between "abc " and "שלום".
+		{"abc שלום", 12, -1, 3},
+		{"abc שלום", 12, 1, 10},
+		{"abc שלום", 10, 1, 8},
+		{"abc שלום", 8, -1, 10},
+		// In a right-to-left paragraph, the logical start is at the right end.
+		{"שלום", 0, -1, 2},
+		{"שלום", 0, 1, 0},
+		{"שלום", 8, 1, 6},
+		{"שלום", 8, -1, 8},
+		// "עולם" is placed right after "abc " in the visual order.
+		// The logical end of "עולם" is at the same position as the offset 4.
+		{"abc שלום 123 עולם", 4, 1, 23},
+		{"abc שלום 123 עולם", 23, -1, 25},
+	}
+	for _, tc := range testCases {
+		if got := d.MoveCaret(tc.str, tc.offset, tc.dir); got != tc.want {
+			t.Errorf("MoveCaret(%q, %d, %d): got: %d, want: %d", tc.str, tc.offset, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestTextFieldCaret(t *testing.T) {
+	buf := "abc"
+	var bounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.TextField(&buf)
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.update()
+	u.click(center(bounds))
+
+	// The caret is at the end first. Backspace deletes the character before the caret.
+	u.pressKey(ebiten.KeyLeft)
+	u.pressKey(ebiten.KeyBackspace)
+	if got, want := buf, "ac"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	u.pressKey(ebiten.KeyRight)
+	u.pressKey(ebiten.KeyBackspace)
+	if got, want := buf, "a"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestLogBuffer(t *testing.T) {
+	b := debugui.NewLogBuffer(3)
+	for i := range 5 {
+		b.Addf(debugui.LogLevelInfo, "test", "message %d", i)
+	}
+	var got []string
+	for _, e := range b.Entries() {
+		got = append(got, e.Message)
+	}
+	if want := []string{"message 2", "message 3", "message 4"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	b.Clear()
+	if got, want := b.Len(), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestLogBufferSlogHandler(t *testing.T) {
+	var b debugui.LogBuffer
+	logger := slog.New(b.SlogHandler("game", slog.LevelInfo))
+	logger.Debug("discarded")
+	logger.With("id", 1).WithGroup("pos").Warn("spawned", "x", 2, "name", "big goblin")
+
+	entries := b.Entries()
+	if got, want := len(entries), 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	e := entries[0]
+	if got, want := e.Message, `spawned id=1 pos.x=2 pos.name="big goblin"`; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := e.Level, debugui.LogLevelWarn; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := e.Channel, "game"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestConsole(t *testing.T) {
+	var c debugui.Console
+	var got []any
+	if err := c.Register(debugui.ConsoleCommand{
+		Name: "spawn",
+		Args: []debugui.ConsoleArg{
+			{Name: "kind", Values: []string{"goblin", "golem", "orc"}},
+			{Name: "count", Type: debugui.ConsoleArgInt, Optional: true},
+		},
+		Run: func(args []any) error {
+			got = args
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register(debugui.ConsoleCommand{Name: "spawn", Run: func(args []any) error { return nil }}); err == nil {
+		t.Errorf("Register with a duplicated name must return an error")
+	}
+
+	if err := c.Execute("spawn goblin 10"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{"goblin", 10}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	for _, line := range []string{"spawn", "spawn dragon", "spawn orc ten", "spawn orc 1 2", "unknown"} {
+		if err := c.Execute(line); err == nil {
+			t.Errorf("Execute(%q) must return an error", line)
+		}
+	}
+
+	testCases := []struct {
+		line       string
+		want       string
+		candidates []string
+	}{
+		{"sp", "spawn ", []string{"spawn"}},
+		{"spawn o", "spawn orc ", []string{"orc"}},
+		{"spawn g", "spawn go", []string{"goblin", "golem"}},
+		{"help cl", "help clear ", []string{"clear"}},
+		{"spawn x", "spawn x", nil},
+	}
+	for _, tc := range testCases {
+		got, candidates := c.Complete(tc.line)
+		if got != tc.want || !slices.Equal(candidates, tc.candidates) {
+			t.Errorf("Complete(%q): got: %q, %q, want: %q, %q", tc.line, got, candidates, tc.want, tc.candidates)
+		}
+	}
+}
+
+// unregisterVarsOnCleanup unregisters the vars with the given paths at the end of the test.
+//
+// Vars are registered in the process-global registry, so each test uses its name as the prefix of the paths
+// and unregisters them not to affect the other tests.
+func unregisterVarsOnCleanup(t *testing.T, paths ...string) {
+	t.Cleanup(func() {
+		for _, path := range paths {
+			debugui.UnregisterVar(path)
+		}
+	})
+}
+
+func TestVarOverrides(t *testing.T) {
+	testBoolVar := debugui.RegisterBoolVar("TestVarOverrides/enabled", true, "")
+	testFloatVar := debugui.RegisterFloatVar("TestVarOverrides/render/bias", 0.5, 0, 1, "")
+	testEnumVar := debugui.RegisterEnumVar("TestVarOverrides/render/quality", 0, []string{"low", "high"}, "")
+	testColorVar := debugui.RegisterColorVar("TestVarOverrides/render/color", color.NRGBA{0xff, 0xff, 0xff, 0xff}, "")
+	unregisterVarsOnCleanup(t, testBoolVar.Path(), testFloatVar.Path(), testEnumVar.Path(), testColorVar.Path())
+
+	testFloatVar.SetValue(2)
+	if got, want := testFloatVar.Value(), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	testEnumVar.SetValue(1)
+	testColorVar.SetValue(color.NRGBA{0x12, 0x34, 0x56, 0x78})
+
+	data, err := debugui.VarOverrides()
+	if err != nil {
+		t.Fatal(err)
+	}
+	debugui.ResetVars()
+	if got, want := testEnumVar.Value(), 0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if err := debugui.SetVarOverrides(data); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := testBoolVar.Value(), true; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testFloatVar.Value(), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testEnumVar.Value(), 1; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testColorVar.Value(), (color.NRGBA{0x12, 0x34, 0x56, 0x78}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if err := debugui.SetVarOverrides([]byte(`{"TestVarOverrides/render/quality": "ultra"}`)); err == nil {
+		t.Errorf("SetVarOverrides with an unknown enum value must return an error")
+	}
+}
+
+func TestVarsReset(t *testing.T) {
+	boolVar := debugui.RegisterBoolVar("TestVarsReset/enabled", true, "")
+	intVar := debugui.RegisterIntVar("TestVarsReset/count", 1, 0, 0, "")
+	unregisterVarsOnCleanup(t, boolVar.Path(), intVar.Path())
+
+	var topBounds, rowResetBounds, resetBounds image.Rectangle
+	debugui.SetVarsResetHook(func(ctx *debugui.Context) {
+		resetBounds = ctx.CurrentBounds()
+	})
+	t.Cleanup(func() {
+		debugui.SetVarsResetHook(nil)
+	})
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Vars", image.Rect(0, 0, 400, 300), func(layout debugui.ContainerLayout) {
+			ctx.Text("")
+			topBounds = ctx.CurrentBounds()
+			ctx.Vars(nil)
+			rowResetBounds = ctx.CurrentBounds()
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	u.updateTicks(2)
+
+	// The vars are sorted by the paths, so the last row is the one for boolVar.
+	boolVar.SetValue(false)
+	intVar.SetValue(5)
+	u.update()
+	u.click(center(rowResetBounds))
+	if got, want := boolVar.Value(), true; got != want {
+		t.Errorf("boolVar after resetting the var: got: %v, want: %v", got, want)
+	}
+	if got, want := intVar.Value(), 5; got != want {
+		t.Errorf("intVar after resetting boolVar: got: %d, want: %d", got, want)
+	}
+
+	resetPt := center(resetBounds)
+	u.click(resetPt)
+	if got, want := intVar.Value(), 5; got != want {
+		t.Errorf("intVar after clicking Reset once: got: %d, want: %d", got, want)
+	}
+
+	// Clicking elsewhere cancels the confirmation.
+	u.click(image.Pt(topBounds.Min.X+2, topBounds.Min.Y+2))
+	u.click(resetPt)
+	if got, want := intVar.Value(), 5; got != want {
+		t.Errorf("intVar after canceling the confirmation: got: %d, want: %d", got, want)
+	}
+
+	u.click(resetPt)
+	if got, want := boolVar.Value(), true; got != want {
+		t.Errorf("boolVar after confirming Reset: got: %v, want: %v", got, want)
+	}
+	if got, want := intVar.Value(), 1; got != want {
+		t.Errorf("intVar after confirming Reset: got: %d, want: %d", got, want)
+	}
+}
+
+func TestShortcutConflict(t *testing.T) {
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.Shortcut("Ctrl+Shift+P")
+		ctx.Shortcut("Shift+P")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.Shortcut("Ctrl+Shift+P")
+		ctx.Shortcut("shift+ctrl+p")
+		return nil
+	}); err == nil {
+		t.Errorf("Update must return an error for a conflicting shortcut")
+	}
+	var d2 debugui.DebugUI
+	if _, err := d2.Update(func(ctx *debugui.Context) error {
+		ctx.Shortcut("Hyper+P")
+		return nil
+	}); err == nil {
+		t.Errorf("Update must return an error for an invalid shortcut")
+	}
+}
+
+func TestShortcutSuppressed(t *testing.T) {
+	var text string
+	var textFieldBounds image.Rectangle
+	var openModal bool
+	var windowPressed, modalPressed bool
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.TextField(&text)
+			textFieldBounds = ctx.CurrentBounds()
+			ctx.Shortcut("F2").On(func() {
+				windowPressed = true
+			})
+			modalID := ctx.Modal("Modal", func(layout debugui.ContainerLayout, modalID debugui.ModalID) {
+				ctx.Shortcut("F3").On(func() {
+					modalPressed = true
+				})
+			})
+			if openModal {
+				ctx.OpenModal(modalID)
+				openModal = false
+			}
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	pressKey := func(key ebiten.Key) {
+		t.Helper()
+		windowPressed = false
+		modalPressed = false
+		u.pressKey(key)
+	}
+
+	u.updateTicks(2)
+	pressKey(ebiten.KeyF2)
+	if !windowPressed {
+		t.Errorf("the shortcut must work without a focused text field or a modal window")
+	}
+
+	// Typing in a text field must not trigger shortcuts.
+	u.click(center(textFieldBounds))
+	pressKey(ebiten.KeyF2)
+	if windowPressed {
+		t.Errorf("the shortcut must not work while the text field has focus")
+	}
+	u.click(image.Pt(299, 299))
+	pressKey(ebiten.KeyF2)
+	if !windowPressed {
+		t.Errorf("the shortcut must work after the text field loses focus")
+	}
+
+	// An open modal window blocks the shortcuts outside it.
+	openModal = true
+	u.updateTicks(3)
+	pressKey(ebiten.KeyF2)
+	if windowPressed {
+		t.Errorf("the shortcut outside the modal window must not work while the modal window is open")
+	}
+	pressKey(ebiten.KeyF3)
+	if !modalPressed {
+		t.Errorf("the shortcut in the modal window must work while the modal window is open")
+	}
+}
+
+func TestHidden(t *testing.T) {
+	var d debugui.DebugUI
+	d.SetVisible(false)
+	var called bool
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		called = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Errorf("the function passed to Update must not be called while the debug UI is hidden")
+	}
+
+	d.SetVisible(true)
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		called = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Errorf("the function passed to Update must be called while the debug UI is visible")
+	}
+
+	if err := d.SetToggleShortcut("Ctrl+Hyper"); err == nil {
+		t.Errorf("SetToggleShortcut with an invalid chord must return an error")
+	}
+	if got, want := d.Opacity(), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	d.SetOpacity(2)
+	if got, want := d.Opacity(), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestToggleShortcut(t *testing.T) {
+	var buf string
+	var bounds image.Rectangle
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.TextField(&buf)
+			bounds = ctx.CurrentBounds()
+		})
+		return nil
+	}
+	u := newTestUI(t, f)
+	if err := u.d.SetToggleShortcut("Ctrl+Shift+D"); err != nil {
+		t.Fatal(err)
+	}
+	u.update()
+
+	// Press the key chord while the modifiers are held.
+	pressChord := func() {
+		u.input.PressKey(ebiten.KeyControl)
+		u.input.PressKey(ebiten.KeyShift)
+		u.pressKey(ebiten.KeyD)
+		u.input.ReleaseKey(ebiten.KeyControl)
+		u.input.ReleaseKey(ebiten.KeyShift)
+		u.update()
+	}
+
+	// The key without the modifiers doesn't toggle the visibility.
+	u.pressKey(ebiten.KeyD)
+	if !u.d.IsVisible() {
+		t.Errorf("the debug UI must be visible after pressing the key without the modifiers")
+	}
+
+	pressChord()
+	if u.d.IsVisible() {
+		t.Errorf("the debug UI must be hidden after pressing the key chord")
+	}
+	pressChord()
+	if !u.d.IsVisible() {
+		t.Errorf("the debug UI must be visible after pressing the key chord again")
+	}
+
+	// Typing the key chord into a text field doesn't hide the debug UI.
+	u.click(center(bounds))
+	pressChord()
+	if !u.d.IsVisible() {
+		t.Errorf("the debug UI must be visible after typing the key chord into a text field")
+	}
+}
+
+func TestToggleShortcutConflict(t *testing.T) {
+	var d debugui.DebugUI
+	if err := d.SetToggleShortcut("F12"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.Shortcut("F12")
+		return nil
+	}); err == nil {
+		t.Errorf("Update must return an error for a shortcut with the toggle key chord")
+	}
+}
+
+func TestScaleAlpha(t *testing.T) {
+	testCases := []struct {
+		clr     color.Color
+		opacity float64
+		want    color.RGBA64
+	}{
+		{color.RGBA64{0x8000, 0x4000, 0x2000, 0x8000}, 1, color.RGBA64{0x8000, 0x4000, 0x2000, 0x8000}},
+		{color.RGBA64{0x8000, 0x4000, 0x2000, 0x8000}, 0.5, color.RGBA64{0x4000, 0x2000, 0x1000, 0x4000}},
+		{color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}, 0, color.RGBA64{}},
+	}
+	for _, tc := range testCases {
+		r, g, b, a := debugui.ScaleAlpha(tc.clr, tc.opacity).RGBA()
+		// The colors are premultiplied, so all the components are multiplied by the opacity.
+		if got := (color.RGBA64{uint16(r), uint16(g), uint16(b), uint16(a)}); got != tc.want {
+			t.Errorf("ScaleAlpha(%v, %v): got: %v, want: %v", tc.clr, tc.opacity, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,696 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+)
+
+const (
+	// dockSplitterSize is the size of the gap between docked regions, which can be dragged to resize the regions.
+	dockSplitterSize = 6
+
+	// dockEdgeRatio is the ratio of the region for a window docked at a screen edge.
+	dockEdgeRatio = 0.25
+)
+
+type dockSide int
+
+const (
+	dockSideCenter dockSide = iota
+	dockSideLeft
+	dockSideRight
+	dockSideTop
+	dockSideBottom
+)
+
+// dockNode is a node of the dock tree.
+//
+// A dockNode is either a split node with two children or a leaf node.
+// A leaf node has windows stacked as tabs.
+// A leaf node without windows is a free region where no window is docked.
+//
+// The exported fields are used for serialization.
+type dockNode struct {
+	// Vertical reports whether the children of a split node are stacked vertically.
+	Vertical bool `json:"vertical,omitempty"`
+
+	// Ratio is the ratio of the first child's size to the node's size for a split node.
+	Ratio float64 `json:"ratio,omitempty"`
+
+	// Children is the children of a split node. Children is empty for a leaf node.
+	Children []*dockNode `json:"children,omitempty"`
+
+	// Windows is the windows docked in a leaf node.
+	Windows []dockedWindow `json:"windows,omitempty"`
+
+	// Active is the index of the active window tab in Windows.
+	Active int `json:"active,omitempty"`
+
+	parent *dockNode
+	bounds image.Rectangle
+}
+
+// dockedWindow is a window docked in a leaf node.
+//
+// A docked window is identified by its ID. The title is used for the tab and for serialization,
+// as an ID is not stable across sessions.
+type dockedWindow struct {
+	// id is the ID of the window.
+	// id is zero for a window restored by SetDockLayout until the window is declared.
+	id widgetID
+
+	title string
+}
+
+func (w dockedWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.title)
+}
+
+func (w *dockedWindow) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &w.title)
+}
+
+type dockTarget struct {
+	// node is the leaf node to dock a window into. If node is nil, the window is docked at a screen edge.
+	node *dockNode
+
+	side dockSide
+}
+
+type dock struct {
+	enabled bool
+	root    *dockNode
+
+	// windows is the containers of the dockable windows declared in the current tick.
+	windows map[widgetID]*container
+
+	// prevWindows is the containers of the dockable windows declared in the previous tick.
+	prevWindows map[widgetID]*container
+
+	draggingSplitter *dockNode
+}
+
+// SetDockingEnabled enables or disables docking windows.
+//
+// When docking is enabled, a window can be docked by dragging its title bar onto a screen edge or onto a docked window.
+// Dropping a window onto an edge of a docked window splits the region,
+// and dropping a window onto the center of a docked window stacks the windows as tabs.
+// The gaps between docked regions can be dragged to resize the regions.
+// A docked window is undocked by dragging its title bar or its tab.
+//
+// Docked windows are identified by their WindowIDs.
+// A docked window keeps its place while the window is closed or not declared,
+// and the window is removed from the dock layout only when the window is undocked.
+// A region where none of the windows is shown is collapsed, and the neighboring region takes the space.
+//
+// When docking is disabled, all the windows are undocked.
+//
+// Docking is disabled by default.
+func (c *Context) SetDockingEnabled(enabled bool) {
+	if c.dock.enabled == enabled {
+		return
+	}
+	c.dock = dock{
+		enabled: enabled,
+	}
+}
+
+// DockLayout returns the current dock layout in a serialized form.
+//
+// The returned data can be passed to SetDockLayout to restore the layout, e.g. in the next session.
+// As WindowIDs are not stable across sessions, the windows are serialized by their titles.
+// The docked windows should have unique titles to restore the layout.
+func (c *Context) DockLayout() ([]byte, error) {
+	return json.Marshal(c.dockRoot())
+}
+
+// SetDockLayout restores the dock layout serialized by DockLayout.
+//
+// SetDockLayout should be called before the windows are declared.
+// The windows in the layout are matched with the declared windows by their titles.
+// The windows in the layout that are not declared keep their places, and are shown there when they are declared.
+//
+// SetDockLayout returns an error if the layout is invalid, e.g. a window title appears more than once.
+func (c *Context) SetDockLayout(data []byte) error {
+	var root dockNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	if err := root.validate(map[string]struct{}{}); err != nil {
+		return err
+	}
+	root.setParent(nil)
+	c.dock.root = &root
+	c.layoutDock()
+	return nil
+}
+
+func (c *Context) dockRoot() *dockNode {
+	if c.dock.root == nil {
+		c.dock.root = &dockNode{}
+	}
+	return c.dock.root
+}
+
+func dockable(opt option) bool {
+	return (opt & (optionNoTitle | optionPopup | optionModal | optionTooltip)) == 0
+}
+
+func (n *dockNode) isLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// validate validates the node and its descendants.
+// titles is the window titles already found in the tree.
+func (n *dockNode) validate(titles map[string]struct{}) error {
+	if n.isLeaf() {
+		if len(n.Windows) > 0 && (n.Active < 0 || n.Active >= len(n.Windows)) {
+			return fmt.Errorf("debugui: active tab index %d is out of range", n.Active)
+		}
+		for _, w := range n.Windows {
+			if _, ok := titles[w.title]; ok {
+				return fmt.Errorf("debugui: window %q is docked more than once", w.title)
+			}
+			titles[w.title] = struct{}{}
+		}
+		return nil
+	}
+	if len(n.Children) != 2 {
+		return fmt.Errorf("debugui: a split dock node must have 2 children but has %d", len(n.Children))
+	}
+	if len(n.Windows) > 0 {
+		return errors.New("debugui: a split dock node must not have windows")
+	}
+	if n.Ratio <= 0 || n.Ratio >= 1 {
+		return fmt.Errorf("debugui: dock split ratio must be in (0, 1) but %f", n.Ratio)
+	}
+	for _, child := range n.Children {
+		if err := child.validate(titles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dockNode) setParent(parent *dockNode) {
+	n.parent = parent
+	for _, child := range n.Children {
+		child.setParent(n)
+	}
+}
+
+// visible reports whether the node has a free region or a shown window in its descendants.
+func (n *dockNode) visible(shown func(id widgetID) bool) bool {
+	if n.isLeaf() {
+		return len(n.Windows) == 0 || slices.ContainsFunc(n.Windows, func(w dockedWindow) bool {
+			return shown(w.id)
+		})
+	}
+	return n.Children[0].visible(shown) || n.Children[1].visible(shown)
+}
+
+// layout calculates the bounds of the node and its descendants.
+//
+// A child that is not visible is collapsed to an empty rectangle, and the other child takes the whole bounds.
+func (n *dockNode) layout(bounds image.Rectangle, shown func(id widgetID) bool) {
+	n.bounds = bounds
+	if n.isLeaf() {
+		return
+	}
+	if bounds.Empty() {
+		n.Children[0].layout(image.Rectangle{}, shown)
+		n.Children[1].layout(image.Rectangle{}, shown)
+		return
+	}
+	if v0, v1 := n.Children[0].visible(shown), n.Children[1].visible(shown); v0 != v1 {
+		if v0 {
+			n.Children[0].layout(bounds, shown)
+			n.Children[1].layout(image.Rectangle{}, shown)
+		} else {
+			n.Children[0].layout(image.Rectangle{}, shown)
+			n.Children[1].layout(bounds, shown)
+		}
+		return
+	}
+	b0, b1 := bounds, bounds
+	if n.Vertical {
+		h := int(float64(bounds.Dy()-dockSplitterSize) * n.Ratio)
+		b0.Max.Y = bounds.Min.Y + h
+		b1.Min.Y = b0.Max.Y + dockSplitterSize
+	} else {
+		w := int(float64(bounds.Dx()-dockSplitterSize) * n.Ratio)
+		b0.Max.X = bounds.Min.X + w
+		b1.Min.X = b0.Max.X + dockSplitterSize
+	}
+	n.Children[0].layout(b0, shown)
+	n.Children[1].layout(b1, shown)
+}
+
+// splitterBounds returns the bounds of the gap between the children of a split node.
+func (n *dockNode) splitterBounds() image.Rectangle {
+	b := n.bounds
+	if n.Vertical {
+		b.Min.Y = n.Children[0].bounds.Max.Y
+		b.Max.Y = n.Children[1].bounds.Min.Y
+	} else {
+		b.Min.X = n.Children[0].bounds.Max.X
+		b.Max.X = n.Children[1].bounds.Min.X
+	}
+	return b
+}
+
+// findWindow returns the leaf node and the index of the first docked window satisfying f.
+func (n *dockNode) findWindow(f func(w dockedWindow) bool) (*dockNode, int) {
+	if n.isLeaf() {
+		if i := slices.IndexFunc(n.Windows, f); i >= 0 {
+			return n, i
+		}
+		return nil, -1
+	}
+	for _, child := range n.Children {
+		if leaf, i := child.findWindow(f); leaf != nil {
+			return leaf, i
+		}
+	}
+	return nil, -1
+}
+
+// leafForWindow returns the leaf node where the window with the given ID is docked.
+func (n *dockNode) leafForWindow(id widgetID) *dockNode {
+	leaf, _ := n.findWindow(func(w dockedWindow) bool {
+		return w.id == id
+	})
+	return leaf
+}
+
+// walkLeaves calls f for each leaf node of the tree whose root is n.
+func (n *dockNode) walkLeaves(f func(leaf *dockNode)) {
+	if n.isLeaf() {
+		f(n)
+		return
+	}
+	for _, child := range n.Children {
+		child.walkLeaves(f)
+	}
+}
+
+// leafAt returns the leaf node with windows at the given position.
+func (n *dockNode) leafAt(p image.Point) *dockNode {
+	if !p.In(n.bounds) {
+		return nil
+	}
+	if n.isLeaf() {
+		if len(n.Windows) == 0 {
+			return nil
+		}
+		return n
+	}
+	for _, child := range n.Children {
+		if leaf := child.leafAt(p); leaf != nil {
+			return leaf
+		}
+	}
+	return nil
+}
+
+// splitterAt returns the split node whose splitter is at the given position.
+//
+// A split node with a collapsed child doesn't have a splitter.
+func (n *dockNode) splitterAt(p image.Point) *dockNode {
+	if n.isLeaf() || !p.In(n.bounds) {
+		return nil
+	}
+	if !n.Children[0].bounds.Empty() && !n.Children[1].bounds.Empty() && p.In(n.splitterBounds()) {
+		return n
+	}
+	for _, child := range n.Children {
+		if node := child.splitterAt(p); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+func (n *dockNode) contains(node *dockNode) bool {
+	if n == node {
+		return true
+	}
+	for _, child := range n.Children {
+		if child.contains(node) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *dockNode) activeWindow() widgetID {
+	if len(n.Windows) == 0 {
+		return widgetID{}
+	}
+	return n.Windows[n.Active].id
+}
+
+// replace replaces the node n with the node by in the tree whose root is *root.
+func (n *dockNode) replace(by *dockNode, root **dockNode) {
+	parent := n.parent
+	by.parent = parent
+	if parent == nil {
+		*root = by
+		return
+	}
+	for i, child := range parent.Children {
+		if child == n {
+			parent.Children[i] = by
+		}
+	}
+}
+
+// split splits the node n and puts the node by at the given side of n.
+// ratio is the ratio of the size of by.
+func (n *dockNode) split(by *dockNode, side dockSide, ratio float64, root **dockNode) {
+	s := &dockNode{
+		Vertical: side == dockSideTop || side == dockSideBottom,
+	}
+	n.replace(s, root)
+	switch side {
+	case dockSideLeft, dockSideTop:
+		s.Children = []*dockNode{by, n}
+		s.Ratio = ratio
+	default:
+		s.Children = []*dockNode{n, by}
+		s.Ratio = 1 - ratio
+	}
+	s.setParent(s.parent)
+}
+
+// removeWindow removes the window with the given ID from the leaf node n.
+// If n has no windows after the removal, n is removed from the tree.
+func (n *dockNode) removeWindow(id widgetID, root **dockNode) {
+	idx := slices.IndexFunc(n.Windows, func(w dockedWindow) bool {
+		return w.id == id
+	})
+	if idx < 0 {
+		return
+	}
+	n.Windows = slices.Delete(n.Windows, idx, idx+1)
+	if n.Active >= len(n.Windows) {
+		n.Active = max(len(n.Windows)-1, 0)
+	}
+	if len(n.Windows) > 0 {
+		return
+	}
+
+	parent := n.parent
+	if parent == nil {
+		*root = &dockNode{}
+		return
+	}
+	sibling := parent.Children[0]
+	if sibling == n {
+		sibling = parent.Children[1]
+	}
+	parent.replace(sibling, root)
+}
+
+// dockTargetAt returns the dock target at the given position.
+func (c *Context) dockTargetAt(p image.Point) (dockTarget, bool) {
+	screen := c.screenBounds()
+	if screen.Empty() {
+		return dockTarget{}, false
+	}
+
+	edge := c.style().titleHeight
+	switch {
+	case p.X < screen.Min.X+edge:
+		return dockTarget{side: dockSideLeft}, true
+	case p.X >= screen.Max.X-edge:
+		return dockTarget{side: dockSideRight}, true
+	case p.Y < screen.Min.Y+edge:
+		return dockTarget{side: dockSideTop}, true
+	case p.Y >= screen.Max.Y-edge:
+		return dockTarget{side: dockSideBottom}, true
+	}
+
+	leaf := c.dockRoot().leafAt(p)
+	if leaf == nil {
+		return dockTarget{}, false
+	}
+	b := leaf.bounds
+	if p.In(b.Inset(min(b.Dx(), b.Dy()) / 4)) {
+		return dockTarget{node: leaf, side: dockSideCenter}, true
+	}
+	left, right := p.X-b.Min.X, b.Max.X-p.X
+	top, bottom := p.Y-b.Min.Y, b.Max.Y-p.Y
+	switch min(left, right, top, bottom) {
+	case left:
+		return dockTarget{node: leaf, side: dockSideLeft}, true
+	case right:
+		return dockTarget{node: leaf, side: dockSideRight}, true
+	case top:
+		return dockTarget{node: leaf, side: dockSideTop}, true
+	default:
+		return dockTarget{node: leaf, side: dockSideBottom}, true
+	}
+}
+
+// dockTargetBounds returns the bounds where a window is placed when the window is docked at the target.
+func (c *Context) dockTargetBounds(target dockTarget) image.Rectangle {
+	b := c.screenBounds()
+	ratio := dockEdgeRatio
+	if target.node != nil {
+		b = target.node.bounds
+		ratio = 0.5
+	}
+	switch target.side {
+	case dockSideLeft:
+		b.Max.X = b.Min.X + int(float64(b.Dx())*ratio)
+	case dockSideRight:
+		b.Min.X = b.Max.X - int(float64(b.Dx())*ratio)
+	case dockSideTop:
+		b.Max.Y = b.Min.Y + int(float64(b.Dy())*ratio)
+	case dockSideBottom:
+		b.Min.Y = b.Max.Y - int(float64(b.Dy())*ratio)
+	}
+	return b
+}
+
+// dockWindow docks the window with the given ID and title at the target.
+func (c *Context) dockWindow(id widgetID, title string, cnt *container, target dockTarget) {
+	cnt.undockedBounds = cnt.layout.Bounds
+
+	w := dockedWindow{
+		id:    id,
+		title: title,
+	}
+	switch {
+	case target.node != nil && target.side == dockSideCenter:
+		target.node.Windows = append(target.node.Windows, w)
+		target.node.Active = len(target.node.Windows) - 1
+	case target.node != nil:
+		target.node.split(&dockNode{Windows: []dockedWindow{w}}, target.side, 0.5, &c.dock.root)
+	default:
+		c.dockRoot().split(&dockNode{Windows: []dockedWindow{w}}, target.side, dockEdgeRatio, &c.dock.root)
+	}
+	c.layoutDock()
+
+	// Docked windows are behind floating windows.
+	c.sendToBack(cnt)
+}
+
+// undockWindow undocks the window with the given ID, and places the window at the pointing position.
+func (c *Context) undockWindow(id widgetID) {
+	leaf := c.dockRoot().leafForWindow(id)
+	if leaf == nil {
+		return
+	}
+	leaf.removeWindow(id, &c.dock.root)
+	c.layoutDock()
+
+	cnt, ok := c.dock.windows[id]
+	if !ok {
+		return
+	}
+	size := cnt.undockedBounds.Size()
+	if size.X <= 0 || size.Y <= 0 {
+		size = cnt.layout.Bounds.Size()
+	}
+	p := c.pointingPosition()
+	pos := p.Sub(image.Pt(size.X/2, c.style().titleHeight/2))
+	cnt.layout.Bounds = image.Rectangle{Min: pos, Max: pos.Add(size)}
+	cnt.dragBounds = cnt.layout.Bounds
+	c.bringToFront(cnt)
+}
+
+// isDockWindowShown reports whether the docked window with the given ID is shown.
+//
+// A window that is not declared in the current or the previous tick, e.g. a closed window, is not shown.
+// The previous tick is also checked as the windows after the active window are not declared yet.
+func (c *Context) isDockWindowShown(id widgetID) bool {
+	if _, ok := c.dock.windows[id]; ok {
+		return true
+	}
+	_, ok := c.dock.prevWindows[id]
+	return ok
+}
+
+// layoutDock calculates the bounds of the dock regions.
+func (c *Context) layoutDock() {
+	c.dockRoot().layout(c.screenBounds(), c.isDockWindowShown)
+}
+
+// dockTabWindows returns the indices of the windows shown as tabs in the leaf node.
+func (c *Context) dockTabWindows(leaf *dockNode) []int {
+	var indices []int
+	for i, w := range leaf.Windows {
+		if c.isDockWindowShown(w.id) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// dockTabs handles the tabs of the windows stacked in the leaf node.
+func (c *Context) dockTabs(leaf *dockNode, bounds image.Rectangle) {
+	windows := slices.Clone(leaf.Windows)
+	indices := c.dockTabWindows(leaf)
+	for j, i := range indices {
+		id, title := windows[i].id, windows[i].title
+		r := bounds
+		r.Min.X = bounds.Min.X + bounds.Dx()*j/len(indices)
+		r.Max.X = bounds.Min.X + bounds.Dx()*(j+1)/len(indices)
+		// The tab ID depends on the window of the tab, not on the window rendering the tabs, as the latter changes.
+		tabID := id.push(idPartFromString("dock-tab"))
+		_ = c.widgetWithBounds(tabID, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if c.focus != tabID {
+				return nil
+			}
+			if c.pointing.justPressed() {
+				leaf.Active = i
+				return nil
+			}
+			// Dragging a tab undocks the window and continues dragging the window's title bar.
+			if c.pointing.pressed() && c.pointingDelta() != (image.Point{}) {
+				c.undockWindow(id)
+				if _, ok := c.dock.windows[id]; ok {
+					c.setFocus(id.push(idPartFromString("title")))
+				}
+			}
+			return nil
+		}, func(bounds image.Rectangle) {
+			if i == leaf.Active {
+				c.drawFrame(bounds, colorButton)
+			} else if c.hover == tabID {
+				c.drawFrame(bounds, colorButtonHover)
+			}
+			c.drawTruncatedWidgetText(title, bounds, colorTitleText, optionAlignCenter)
+		})
+	}
+}
+
+// updateDockSplitters handles dragging the splitters between docked regions.
+//
+// updateDockSplitters returns true if the pointing device is on or dragging a splitter.
+func (c *Context) updateDockSplitters() bool {
+	if !c.dock.enabled {
+		return false
+	}
+
+	p := c.pointingPosition()
+	if n := c.dock.draggingSplitter; n != nil {
+		if !c.pointing.pressed() {
+			c.dock.draggingSplitter = nil
+			return false
+		}
+		b := n.bounds
+		if n.Vertical {
+			n.Ratio = float64(p.Y-b.Min.Y) / float64(max(b.Dy(), 1))
+		} else {
+			n.Ratio = float64(p.X-b.Min.X) / float64(max(b.Dx(), 1))
+		}
+		n.Ratio = clamp(n.Ratio, 0.05, 0.95)
+		c.layoutDock()
+		return true
+	}
+
+	if c.hoveringRootContainer() != nil {
+		return false
+	}
+	n := c.dockRoot().splitterAt(p)
+	if n == nil {
+		return false
+	}
+	if c.pointing.justPressed() {
+		c.dock.draggingSplitter = n
+	}
+	return true
+}
+
+func (c *Context) beginDock() {
+	if !c.dock.enabled {
+		return
+	}
+	c.dock.windows, c.dock.prevWindows = c.dock.prevWindows, c.dock.windows
+	clear(c.dock.windows)
+	c.layoutDock()
+}
+
+// endDock activates another tab of a leaf node if the active window is not declared in the current tick.
+//
+// The windows that are not declared, e.g. closed windows, are not removed from the dock tree,
+// so that they are shown at the same places when they are declared again.
+func (c *Context) endDock() {
+	if !c.dock.enabled {
+		return
+	}
+	c.dockRoot().walkLeaves(func(leaf *dockNode) {
+		if _, ok := c.dock.windows[leaf.activeWindow()]; ok {
+			return
+		}
+		for i, w := range leaf.Windows {
+			if _, ok := c.dock.windows[w.id]; ok {
+				leaf.Active = i
+				return
+			}
+		}
+	})
+	if n := c.dock.draggingSplitter; n != nil && !c.dockRoot().contains(n) {
+		c.dock.draggingSplitter = nil
+	}
+}
+
+// registerDockWindow registers the dockable window declared in the current tick,
+// and returns the leaf node where the window is docked, or nil if the window is not docked.
+func (c *Context) registerDockWindow(title string, cnt *container, id widgetID) *dockNode {
+	if c.dock.windows == nil {
+		c.dock.windows = map[widgetID]*container{}
+	}
+	c.dock.windows[id] = cnt
+
+	leaf, i := c.dockRoot().findWindow(func(w dockedWindow) bool {
+		return w.id == id
+	})
+	if leaf == nil {
+		// A window restored by SetDockLayout is matched by its title.
+		leaf, i = c.dockRoot().findWindow(func(w dockedWindow) bool {
+			return w.id == (widgetID{}) && w.title == title
+		})
+		if leaf == nil {
+			return nil
+		}
+		leaf.Windows[i].id = id
+	}
+	// The title might change, e.g. for a title showing a state.
+	leaf.Windows[i].title = title
+
+	// The region might be collapsed as none of the windows was shown. Show the region again.
+	if leaf.bounds.Empty() {
+		c.layoutDock()
+	}
+	return leaf
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
+	"sort"
 	"sync"
 
 	"github.com/hajimehoshi/bitmapfont/v4"
@@ -26,22 +28,55 @@ var (
 	unclippedRect = image.Rect(0, 0, 0x1000000, 0x1000000)
 )
 
-var fontFace = text.NewGoXFace(bitmapfont.Face)
+var defaultFontFace = text.NewGoXFace(bitmapfont.Face)
 
 // DrawText draws the text on the destination image with the given options,
-// in the same way as debugui's widget text drawing.
+// in the same way as debugui's widget text drawing with the default font face.
 //
 // Note that you have to specify the scale at the options when the context scale is not 1.
+//
+// If a font face is specified by [DebugUI.SetFontFace], use [Context.DrawText] instead.
 func DrawText(dst *ebiten.Image, str string, options *text.DrawOptions) {
-	text.Draw(dst, str, fontFace, options)
+	text.Draw(dst, str, defaultFontFace, options)
+}
+
+// DrawText draws the text on the destination image with the given options,
+// in the same way as debugui's widget text drawing with the context's font face.
+//
+// Note that you have to specify the scale at the options when the context scale is not 1.
+func (c *Context) DrawText(dst *ebiten.Image, str string, options *text.DrawOptions) {
+	text.Draw(dst, str, c.fontFace(), options)
+}
+
+func (c *Context) fontFace() text.Face {
+	if c.face == nil {
+		return defaultFontFace
+	}
+	return c.face
 }
 
-func textWidth(str string) int {
-	return int(text.Advance(str, fontFace))
+func (c *Context) textWidth(str string) int {
+	return bidiTextWidth(str, c.fontFace())
 }
 
-func lineHeight() int {
-	return int(fontFace.Metrics().HAscent + fontFace.Metrics().HDescent + fontFace.Metrics().HLineGap)
+func (c *Context) styledTextWidth(str string, bold bool) int {
+	if !bold {
+		return c.textWidth(str)
+	}
+	if c.boldFace != nil {
+		return bidiTextWidth(str, c.boldFace)
+	}
+	// Faux bold text is drawn twice with a 1-pixel offset.
+	return c.textWidth(str) + 1
+}
+
+func (c *Context) lineHeight() int {
+	return faceLineHeight(c.fontFace())
+}
+
+func faceLineHeight(face text.Face) int {
+	m := face.Metrics()
+	return int(m.HAscent + m.HDescent + m.HLineGap)
 }
 
 type icon int
@@ -52,6 +87,7 @@ const (
 	iconExpanded
 	iconDown
 	iconUp
+	iconClose
 )
 
 var (
@@ -81,6 +117,8 @@ func iconImage(icon icon) *ebiten.Image {
 		name = "down.png"
 	case iconUp:
 		name = "up.png"
+	case iconClose:
+		name = "close.png"
 	default:
 		return nil
 	}
@@ -102,25 +140,46 @@ func (c *Context) draw(screen *ebiten.Image) {
 	}
 
 	target := screen
-	scale := c.Scale()
+	scale := c.ScaleF()
+	opacity := c.opacity()
 	for cmd := range c.commands() {
 		switch cmd.typ {
 		case commandRect:
+			r := scaleRect(cmd.rect.rect, scale)
 			vector.DrawFilledRect(
 				target,
-				float32(cmd.rect.rect.Min.X*scale),
-				float32(cmd.rect.rect.Min.Y*scale),
-				float32(cmd.rect.rect.Dx()*scale),
-				float32(cmd.rect.rect.Dy()*scale),
-				cmd.rect.color,
+				float32(r.Min.X),
+				float32(r.Min.Y),
+				float32(r.Dx()),
+				float32(r.Dy()),
+				scaleAlpha(cmd.rect.color, opacity),
 				false,
 			)
 		case commandText:
-			op := &text.DrawOptions{}
-			op.GeoM.Translate(float64(cmd.text.pos.X), float64(cmd.text.pos.Y))
-			op.GeoM.Scale(float64(scale), float64(scale))
-			op.ColorScale.ScaleWithColor(cmd.text.color)
-			text.Draw(target, cmd.text.str, fontFace, op)
+			face := c.fontFace()
+			var fauxBold bool
+			if cmd.text.bold {
+				if c.boldFace != nil {
+					face = c.boldFace
+				} else {
+					fauxBold = true
+				}
+			}
+			face, scaled := c.scaledFontFace(face, scale)
+			for i := range 1 + boolToInt(fauxBold) {
+				op := &text.DrawOptions{}
+				x, y := float64(cmd.text.pos.X+i), float64(cmd.text.pos.Y)
+				if scaled {
+					// The font is rendered at the scaled size for crisp text.
+					op.GeoM.Translate(math.Round(x*scale), math.Round(y*scale))
+				} else {
+					op.GeoM.Translate(x, y)
+					op.GeoM.Scale(scale, scale)
+				}
+				op.ColorScale.ScaleWithColor(cmd.text.color)
+				op.ColorScale.ScaleAlpha(float32(opacity))
+				drawBidiText(target, cmd.text.str, face, op)
+			}
 		case commandIcon:
 			img := iconImage(cmd.icon.icon)
 			if img == nil {
@@ -130,22 +189,83 @@ func (c *Context) draw(screen *ebiten.Image) {
 			x := cmd.icon.rect.Min.X + (cmd.icon.rect.Dx()-img.Bounds().Dx())/2
 			y := cmd.icon.rect.Min.Y + (cmd.icon.rect.Dy()-img.Bounds().Dy())/2
 			op.GeoM.Translate(float64(x), float64(y))
-			op.GeoM.Scale(float64(scale), float64(scale))
+			op.GeoM.Scale(scale, scale)
 			op.ColorScale.ScaleWithColor(cmd.icon.color)
+			op.ColorScale.ScaleAlpha(float32(opacity))
+			if scale != math.Trunc(scale) {
+				op.Filter = ebiten.FilterLinear
+			}
 			target.DrawImage(img, op)
 		case commandDraw:
 			cmd.draw.f(target)
 		case commandClip:
-			r := cmd.clip.rect
-			r.Min.X *= scale
-			r.Min.Y *= scale
-			r.Max.X *= scale
-			r.Max.Y *= scale
-			target = screen.SubImage(r).(*ebiten.Image)
+			target = screen.SubImage(scaleRect(cmd.clip.rect, scale)).(*ebiten.Image)
 		}
 	}
 }
 
+func (c *Context) opacity() float64 {
+	return c.opacityMinus1 + 1
+}
+
+// scaleAlpha returns the color multiplied by the opacity.
+func scaleAlpha(clr color.Color, opacity float64) color.Color {
+	if opacity == 1 {
+		return clr
+	}
+	r, g, b, a := clr.RGBA()
+	return color.RGBA64{
+		R: uint16(float64(r) * opacity),
+		G: uint16(float64(g) * opacity),
+		B: uint16(float64(b) * opacity),
+		A: uint16(float64(a) * opacity),
+	}
+}
+
+// scaleRect scales the rectangle by rounding the edges, so that adjacent rectangles don't have gaps.
+func scaleRect(r image.Rectangle, scale float64) image.Rectangle {
+	return image.Rect(
+		int(math.Round(float64(r.Min.X)*scale)),
+		int(math.Round(float64(r.Min.Y)*scale)),
+		int(math.Round(float64(r.Max.X)*scale)),
+		int(math.Round(float64(r.Max.Y)*scale)),
+	)
+}
+
+// scaledFontFace returns the font face to render text at the given scale.
+// If the font face can be rendered at the scaled size, scaledFontFace returns the scaled face and true.
+// Otherwise, scaledFontFace returns the font face as it is and false.
+func (c *Context) scaledFontFace(face text.Face, scale float64) (text.Face, bool) {
+	if scale == 1 {
+		return face, false
+	}
+	f, ok := face.(*text.GoTextFace)
+	if !ok {
+		return face, false
+	}
+	if c.scaledFacesScale != scale {
+		clear(c.scaledFaces)
+		c.scaledFacesScale = scale
+	}
+	if scaled, ok := c.scaledFaces[face]; ok {
+		return scaled, true
+	}
+	f2 := *f
+	f2.Size *= scale
+	if c.scaledFaces == nil {
+		c.scaledFaces = map[text.Face]text.Face{}
+	}
+	c.scaledFaces[face] = &f2
+	return &f2, true
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (c *Context) drawRect(rect image.Rectangle, color color.Color) {
 	rect2 := rect.Intersect(c.clipRect())
 	if rect2.Dx() > 0 && rect2.Dy() > 0 {
@@ -163,7 +283,11 @@ func (c *Context) drawBox(rect image.Rectangle, color color.Color) {
 }
 
 func (c *Context) drawText(str string, pos image.Point, color color.Color) {
-	rect := image.Rect(pos.X, pos.Y, pos.X+textWidth(str), pos.Y+lineHeight())
+	c.drawStyledText(str, pos, color, false)
+}
+
+func (c *Context) drawStyledText(str string, pos image.Point, color color.Color, bold bool) {
+	rect := image.Rect(pos.X, pos.Y, pos.X+c.styledTextWidth(str, bold), pos.Y+c.lineHeight())
 	clipped := c.checkClip(rect)
 	if clipped == clipAll {
 		return
@@ -176,6 +300,7 @@ func (c *Context) drawText(str string, pos image.Point, color color.Color) {
 	cmd.text.str = str
 	cmd.text.pos = pos
 	cmd.text.color = color
+	cmd.text.bold = bold
 	// reset clipping if it was set
 	if clipped != 0 {
 		c.setClip(unclippedRect)
@@ -239,12 +364,13 @@ func (c *Context) drawWidgetFrame(id widgetID, rect image.Rectangle, colorid int
 
 func (c *Context) drawWidgetText(str string, rect image.Rectangle, colorid int, opt option) {
 	var pos image.Point
-	tw := textWidth(str)
+	tw := c.textWidth(str)
 	c.pushClipRect(rect)
-	pos.Y = rect.Min.Y + (rect.Dy()-lineHeight())/2
+	pos.Y = rect.Min.Y + (rect.Dy()-c.lineHeight())/2
 	if (opt & optionAlignCenter) != 0 {
 		pos.X = rect.Min.X + (rect.Dx()-tw)/2
-	} else if (opt & optionAlignRight) != 0 {
+	} else if (opt&optionAlignRight) != 0 || isRTLParagraph(str) {
+		// A right-to-left paragraph is aligned to the right by default.
 		pos.X = rect.Min.X + rect.Dx() - tw - c.style().padding
 	} else {
 		pos.X = rect.Min.X + c.style().padding
@@ -253,6 +379,38 @@ func (c *Context) drawWidgetText(str string, rect image.Rectangle, colorid int,
 	c.popClipRect()
 }
 
+// drawTruncatedWidgetText draws the text like drawWidgetText,
+// but the text is truncated with an ellipsis if the text doesn't fit in rect.
+// The full text of a truncated text is shown as a tooltip.
+func (c *Context) drawTruncatedWidgetText(str string, rect image.Rectangle, colorid int, opt option) {
+	if truncated, ok := c.truncateText(str, rect.Dx()-c.style().padding*2); ok {
+		c.setTooltip(rect, str)
+		str = truncated
+	}
+	c.drawWidgetText(str, rect, colorid, opt)
+}
+
+// truncateText returns the text truncated with an ellipsis to fit in the width, and true if the text is truncated.
+func (c *Context) truncateText(str string, width int) (string, bool) {
+	if c.textWidth(str) <= width {
+		return str, false
+	}
+
+	const ellipsis = "…"
+	var offsets []int
+	for i := range str {
+		offsets = append(offsets, i)
+	}
+	// Find the smallest number of runes that don't fit with the ellipsis.
+	n := sort.Search(len(offsets), func(i int) bool {
+		return c.textWidth(removeSpaceAtLineTail(str[:offsets[i]])+ellipsis) > width
+	})
+	if n == 0 {
+		return ellipsis, true
+	}
+	return removeSpaceAtLineTail(str[:offsets[n-1]]) + ellipsis, true
+}
+
 func (c *Context) setClip(rect image.Rectangle) {
 	cmd := c.appendCommand(commandClip)
 	cmd.clip.rect = rect
@@ -285,20 +443,82 @@ func (c *Context) checkClip(bounds image.Rectangle) int {
 // SetScale sets the scale of the UI.
 //
 // The scale affects the rendering result of the UI.
+// To set a fractional scale, use SetScaleF.
 //
 // The default scale is 1.
 func (c *Context) SetScale(scale int) {
 	if scale < 1 {
 		panic("debugui: scale must be >= 1")
 	}
-	c.scaleMinus1 = scale - 1
+	c.SetScaleF(float64(scale))
 }
 
 // Scale returns the scale of the UI.
+//
+// Scale returns ScaleF rounded down, but at least 1.
+// To get the exact scale, e.g. for a fractional scale or the device scale factor, use ScaleF.
 func (c *Context) Scale() int {
-	return c.scaleMinus1 + 1
+	return max(int(c.ScaleF()), 1)
+}
+
+// SetScaleF sets the scale of the UI.
+//
+// SetScaleF is the same as SetScale, but the scale can be fractional, e.g. 1.5.
+//
+// Text is rendered at the scaled size for crisp text only when the font face is a [text.GoTextFace] set by [DebugUI.SetFontFace].
+// The default font face is a bitmap font face that cannot be rendered at an arbitrary size,
+// so the rendered glyphs are scaled, and text looks blurry at a fractional scale.
+func (c *Context) SetScaleF(scale float64) {
+	if scale <= 0 {
+		panic("debugui: scale must be > 0")
+	}
+	c.scaleMinus1 = scale - 1
+}
+
+// ScaleF returns the scale of the UI.
+//
+// If the device scale factor is enabled by SetDeviceScaleFactorEnabled,
+// ScaleF returns the scale multiplied by the device scale factor.
+func (c *Context) ScaleF() float64 {
+	scale := c.scaleMinus1 + 1
+	if c.deviceScaleFactorEnabled && c.deviceScaleFactor > 0 {
+		scale *= c.deviceScaleFactor
+	}
+	return scale
+}
+
+// updateDeviceScaleFactor updates the cached device scale factor of the current monitor.
+func (c *Context) updateDeviceScaleFactor() {
+	if !c.deviceScaleFactorEnabled {
+		return
+	}
+	if m := ebiten.Monitor(); m != nil {
+		c.deviceScaleFactor = m.DeviceScaleFactor()
+	}
+}
+
+// SetDeviceScaleFactorEnabled sets whether the UI is scaled by the device scale factor of the current monitor
+// in addition to the scale specified by SetScale or SetScaleF.
+//
+// This is useful when the game's Layout returns the size in device pixels for high-DPI displays.
+//
+// The device scale factor is disabled by default.
+func (c *Context) SetDeviceScaleFactorEnabled(enabled bool) {
+	c.deviceScaleFactorEnabled = enabled
+	c.updateDeviceScaleFactor()
 }
 
 func (c *Context) style() *style {
-	return &defaultStyle
+	if c.currentStyle == nil {
+		s := defaultStyle
+		// Grow the metrics depending on the line height for a larger font face.
+		lh := c.lineHeight()
+		s.indent = lh
+		if d := lh - faceLineHeight(defaultFontFace); d > 0 {
+			s.defaultHeight += d
+			s.titleHeight += d
+		}
+		c.currentStyle = &s
+	}
+	return c.currentStyle
 }
@@ -13,10 +13,10 @@ import (
 // selectedIndex is a pointer to the currently selected option index (0-based).
 // options is a slice of strings representing the available choices.
 // Returns an EventHandler that triggers when the selection changes.
-func (c *Context) Dropdown(selectedIndex *int, options []string) EventHandler {
+func (c *Context) Dropdown(selectedIndex *int, options []string) WidgetEventHandler {
 	pc := caller()
 	idPart := idPartFromCaller(pc)
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.dropdown(selectedIndex, options, idPart)
 	})
 }
@@ -129,7 +129,7 @@ func (c *Context) dropdown(selectedIndex *int, options []string, idPart string)
 		arrowWidth := bounds.Dy()
 		textBounds := bounds
 		textBounds.Max.X -= arrowWidth
-		c.drawWidgetText(options[*selectedIndex], textBounds, colorText, optionAlignCenter)
+		c.drawTruncatedWidgetText(options[*selectedIndex], textBounds, colorText, optionAlignCenter)
 
 		arrowBounds := image.Rect(bounds.Max.X-arrowWidth, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
 		icon := iconDown
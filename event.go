@@ -3,19 +3,61 @@
 
 package debugui
 
+import "image"
+
 // EventHandler is an interface for handling events in a widget.
 type EventHandler interface {
 	// On registers a callback function to be called when the event occurs.
 	On(func())
 }
 
+// WidgetEventHandler is an EventHandler returned by a widget.
+type WidgetEventHandler interface {
+	EventHandler
+
+	// Tooltip sets a tooltip text for the widget that returned the WidgetEventHandler,
+	// and returns the WidgetEventHandler itself so that On can be chained.
+	//
+	// For example, ctx.Button("Spawn").Tooltip("Spawns enemies").On(spawn) creates a button with a tooltip.
+	// See [Context.Tooltip] for when the tooltip is shown.
+	Tooltip(text string) WidgetEventHandler
+}
+
+// eventWidget is the widget that returned an EventHandler.
+//
+// A zero eventWidget represents no widget, and a tooltip for it is ignored.
+type eventWidget struct {
+	ctx    *Context
+	bounds image.Rectangle
+}
+
+func (w *eventWidget) setTooltip(text string) {
+	if w.ctx == nil {
+		return
+	}
+	w.ctx.setTooltip(w.bounds, text)
+}
+
 type eventHandler struct {
+	widget eventWidget
 }
 
 func (o *eventHandler) On(f func()) {
 	f()
 }
 
-type nullEventHandler struct{}
+func (o *eventHandler) Tooltip(text string) WidgetEventHandler {
+	o.widget.setTooltip(text)
+	return o
+}
+
+type nullEventHandler struct {
+	widget eventWidget
+}
 
 func (n *nullEventHandler) On(func()) {}
+
+func (n *nullEventHandler) Tooltip(text string) WidgetEventHandler {
+	n.widget.setTooltip(text)
+	return n
+}
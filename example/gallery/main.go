@@ -10,6 +10,7 @@ import (
 	"image"
 	"image/color"
 	_ "image/jpeg"
+	"log/slog"
 	"math/rand/v2"
 	"os"
 	"strings"
@@ -17,11 +18,20 @@ import (
 	"github.com/ebitengine/debugui"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 //go:embed gophers.jpg
 var gophersJPG []byte
 
+var (
+	backgroundColor = debugui.RegisterColorVar("render/background", color.NRGBA{0x40, 0x40, 0x80, 0xff}, "Background color of the screen.")
+	gopherVisible   = debugui.RegisterBoolVar("render/gopher/visible", true, "Whether the gopher is drawn.")
+	gopherAlpha     = debugui.RegisterFloatVar("render/gopher/alpha", 1, 0, 1, "Opacity of the gopher.")
+	gopherFilter    = debugui.RegisterEnumVar("render/gopher/filter", 0, []string{"nearest", "linear"}, "Filter to draw the gopher.")
+	statusMessage   = debugui.RegisterStringVar("debug/status", "", "Message shown at the top-left corner.")
+)
+
 type Game struct {
 	gopherImage       *ebiten.Image
 	x                 int
@@ -29,6 +39,7 @@ type Game struct {
 	vx                int
 	vy                int
 	hiRes             bool
+	opacity           float64
 	needResetPosition bool
 	screenWidth       int
 	screenHeight      int
@@ -36,11 +47,15 @@ type Game struct {
 	debugUI             debugui.DebugUI
 	inputCapturingState debugui.InputCapturingState
 
-	logBuf       string
+	logBuffer    debugui.LogBuffer
+	logger       *slog.Logger
 	logSubmitBuf string
-	logUpdated   bool
+	console      debugui.Console
 	bg           [3]int
 	checks       [3]bool
+	toggle       bool
+	switchOn     bool
+	showEntities bool
 	num1_1       int
 	num1_2       int
 	num2         int
@@ -52,9 +67,23 @@ type Game struct {
 	text2        string
 
 	selectedOption1, selectedOption2   int
+	selectedSprite                     int
+	spriteNames                        []string
+	enemies                            []enemy
+	enemySort                          debugui.TableSort
+	enemySelection                     []int
+	selectedLayer                      int
+	logChannels                        [4]bool
+	radio                              int
+	segment                            int
 	dropdownOptions1, dropdownOptions2 []string
 }
 
+type enemy struct {
+	name string
+	hp   int
+}
+
 func NewGame() (*Game, error) {
 	img, _, err := image.Decode(bytes.NewReader(gophersJPG))
 	if err != nil {
@@ -70,11 +99,93 @@ func NewGame() (*Game, error) {
 		needResetPosition: true,
 		text1:             "Hello",
 		text2:             "World",
+		showEntities:      true,
+		opacity:           1,
+	}
+	if err := g.debugUI.SetToggleShortcut("F12"); err != nil {
+		return nil, err
+	}
+	g.logger = slog.New(g.logBuffer.SlogHandler("game", slog.LevelDebug))
+	for i := range 5000 {
+		g.spriteNames = append(g.spriteNames, fmt.Sprintf("sprite_%04d", i))
+	}
+	for i := range 100 {
+		g.enemies = append(g.enemies, enemy{
+			name: fmt.Sprintf("Enemy %d", i),
+			hp:   rand.IntN(100),
+		})
+	}
+
+	if err := g.registerCommands(); err != nil {
+		return nil, err
 	}
 
 	return g, nil
 }
 
+func (g *Game) registerCommands() error {
+	if err := g.console.Register(debugui.ConsoleCommand{
+		Name: "spawn",
+		Help: "Adds an enemy to the enemy table.",
+		Args: []debugui.ConsoleArg{
+			{Name: "name"},
+			{Name: "hp", Type: debugui.ConsoleArgInt, Optional: true},
+		},
+		Run: func(args []any) error {
+			e := enemy{
+				name: args[0].(string),
+				hp:   100,
+			}
+			if len(args) > 1 {
+				e.hp = args[1].(int)
+			}
+			g.enemies = append(g.enemies, e)
+			g.sortEnemies()
+			g.console.Printf("Spawned %s (HP: %d)", e.name, e.hp)
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+	if err := g.console.Register(debugui.ConsoleCommand{
+		Name: "set",
+		Help: "Sets a game parameter.",
+		Args: []debugui.ConsoleArg{
+			{Name: "name", Values: []string{"speed"}},
+			{Name: "value", Type: debugui.ConsoleArgInt},
+		},
+		Run: func(args []any) error {
+			v := args[1].(int)
+			if v < 0 {
+				return fmt.Errorf("speed must not be negative: %d", v)
+			}
+			g.vx = v * sign(g.vx)
+			g.vy = v * sign(g.vy)
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+	if err := g.console.Register(debugui.ConsoleCommand{
+		Name: "reset",
+		Help: "Resets the position of the gopher.",
+		Run: func(args []any) error {
+			g.needResetPosition = true
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
+
 func (g *Game) resetPosition() {
 	sW, sH := g.screenWidth, g.screenHeight
 	if sW == 0 || sH == 0 {
@@ -83,6 +194,7 @@ func (g *Game) resetPosition() {
 	imgW, imgH := g.gopherImage.Bounds().Dx(), g.gopherImage.Bounds().Dy()
 	g.x = rand.IntN(sW - imgW)
 	g.y = rand.IntN(sH - imgH)
+	g.logger.Info("reset position", "x", g.x, "y", g.y)
 }
 
 func (g *Game) Update() error {
@@ -97,18 +209,25 @@ func (g *Game) Update() error {
 	g.y += g.vy
 	if g.x < 0 || sW-imgW <= g.x {
 		g.vx *= -1
+		g.logger.Debug("bounced", "x", g.x, "y", g.y)
 	}
 	if g.y < 0 || sH-imgH <= g.y {
 		g.vy *= -1
+		g.logger.Debug("bounced", "x", g.x, "y", g.y)
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+	if g.inputCapturingState&debugui.InputCapturingStateFocus == 0 && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		return ebiten.Termination
 	}
 	inputCaptured, err := g.debugUI.Update(func(ctx *debugui.Context) error {
+		ctx.SetDockingEnabled(true)
 		g.testWindow(ctx)
 		g.logWindow(ctx)
+		g.consoleWindow(ctx)
+		g.varsWindow(ctx)
 		g.buttonWindows(ctx)
+		g.entityWindow(ctx)
+		g.hudWindow(ctx)
 		return nil
 	})
 	if err != nil {
@@ -119,10 +238,16 @@ func (g *Game) Update() error {
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.Fill(color.RGBA{0x40, 0x40, 0x80, 0xff})
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(g.x), float64(g.y))
-	screen.DrawImage(g.gopherImage, op)
+	screen.Fill(backgroundColor.Value())
+	if gopherVisible.Value() {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(g.x), float64(g.y))
+		op.ColorScale.ScaleAlpha(float32(gopherAlpha.Value()))
+		if gopherFilter.Value() == 1 {
+			op.Filter = ebiten.FilterLinear
+		}
+		screen.DrawImage(g.gopherImage, op)
+	}
 
 	var msgs []string
 	if g.inputCapturingState&debugui.InputCapturingStateHover != 0 {
@@ -131,7 +256,11 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if g.inputCapturingState&debugui.InputCapturingStateFocus != 0 {
 		msgs = append(msgs, "Focusing")
 	}
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("Input Capturing State: %s", strings.Join(msgs, ", ")))
+	msg := fmt.Sprintf("Input Capturing State: %s", strings.Join(msgs, ", "))
+	if status := statusMessage.Value(); status != "" {
+		msg += "\n" + status
+	}
+	ebitenutil.DebugPrint(screen, msg)
 
 	g.debugUI.Draw(screen)
 }
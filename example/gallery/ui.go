@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"slices"
+	"strings"
 
 	"github.com/ebitengine/debugui"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -15,11 +17,7 @@ import (
 )
 
 func (g *Game) writeLog(text string) {
-	if len(g.logBuf) > 0 {
-		g.logBuf += "\n"
-	}
-	g.logBuf += text
-	g.logUpdated = true
+	g.logBuffer.Add(debugui.LogLevelInfo, "ui", text)
 }
 
 func (g *Game) testWindow(ctx *debugui.Context) {
@@ -27,9 +25,21 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 		ctx.Header("Window Info", false, func() {
 			ctx.SetGridLayout([]int{-1, -1}, nil)
 			ctx.Text("Position:")
-			ctx.Text(fmt.Sprintf("%d, %d", layout.Bounds.Min.X, layout.Bounds.Min.Y))
+			ctx.TextWithAlign(fmt.Sprintf("%d, %d", layout.Bounds.Min.X, layout.Bounds.Min.Y), debugui.TextAlignRight)
 			ctx.Text("Size:")
-			ctx.Text(fmt.Sprintf("%d, %d", layout.Bounds.Dx(), layout.Bounds.Dy()))
+			ctx.TextWithAlign(fmt.Sprintf("%d, %d", layout.Bounds.Dx(), layout.Bounds.Dy()), debugui.TextAlignRight)
+			ctx.SetGridLayout([]int{-1, -1, -1}, nil)
+			ctx.Button("Tile").On(func() {
+				ctx.TileWindows()
+			})
+			ctx.Button("Cascade").On(func() {
+				ctx.CascadeWindows()
+			})
+			ctx.Button("Reset").On(func() {
+				ctx.ResetWindows()
+			})
+			ctx.SetGridLayout(nil, nil)
+			ctx.Checkbox(&g.showEntities, "Show Entity List")
 		})
 		ctx.Header("Game Config", true, func() {
 			ctx.Checkbox(&g.hiRes, "Hi-Res").On(func() {
@@ -40,6 +50,27 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 				}
 				g.needResetPosition = true
 			})
+			ctx.SetGridLayout([]int{-1, -2}, nil)
+			ctx.Text("Opacity (F12 to hide)")
+			ctx.SliderF(&g.opacity, 0.2, 1, 0.05, 2).On(func() {
+				g.debugUI.SetOpacity(g.opacity)
+			})
+			ctx.SetGridLayout(nil, nil)
+			modalID := ctx.Modal("Confirm", func(layout debugui.ContainerLayout, id debugui.ModalID) {
+				ctx.SetGridLayout([]int{160}, nil)
+				ctx.Text("Really reset the position?")
+				ctx.SetGridLayout([]int{78, 78}, nil)
+				ctx.Button("Reset").On(func() {
+					g.needResetPosition = true
+					ctx.CloseModal(id)
+				})
+				ctx.Button("Cancel").On(func() {
+					ctx.CloseModal(id)
+				})
+			})
+			ctx.Button("Reset Position").Tooltip("Moves the gophers to a random position after confirmation").On(func() {
+				ctx.OpenModal(modalID)
+			})
 		})
 		ctx.Header("Test Buttons", true, func() {
 			ctx.SetGridLayout([]int{-2, -1, -1}, nil)
@@ -61,7 +92,7 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 					ctx.ClosePopup(id)
 				})
 			})
-			ctx.Button("Popup").On(func() {
+			ctx.Button("Popup").Tooltip("Opens a popup at the pointing position").On(func() {
 				ctx.OpenPopup(popupID)
 			})
 		})
@@ -77,6 +108,59 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 			ctx.Dropdown(&g.selectedOption2, g.dropdownOptions2).On(func() {
 				g.writeLog(fmt.Sprintf("Selected another option: %s", g.dropdownOptions2[g.selectedOption2]))
 			})
+			ctx.Text("Search a sprite:")
+			ctx.ComboBox(&g.selectedSprite, g.spriteNames).On(func() {
+				g.writeLog(fmt.Sprintf("Selected sprite: %s", g.spriteNames[g.selectedSprite]))
+			})
+		})
+		ctx.Header("Table", false, func() {
+			ctx.SetGridLayout([]int{-1}, []int{160})
+			columns := []debugui.TableColumn{
+				{Name: "Name", Width: 120},
+				{Name: "HP", Width: 60},
+			}
+			options := &debugui.TableOptions{
+				Sort:        &g.enemySort,
+				Selection:   &g.enemySelection,
+				MultiSelect: true,
+			}
+			ctx.Table(columns, len(g.enemies), options, func(row, column int) {
+				e := g.enemies[row]
+				switch column {
+				case 0:
+					ctx.Text(e.name)
+				case 1:
+					ctx.Text(fmt.Sprintf("%d", e.hp))
+				}
+			}).On(func() {
+				g.sortEnemies()
+			})
+		})
+		ctx.Header("List Box", false, func() {
+			ctx.SetGridLayout([]int{-1, -1}, []int{80})
+			ctx.ListBox(&g.selectedLayer, []string{"Background", "Terrain", "Objects", "Characters", "Effects", "UI"}).On(func() {
+				g.writeLog(fmt.Sprintf("Selected layer: %d", g.selectedLayer))
+			})
+			ctx.MultiSelectListBox(g.logChannels[:], []string{"Render", "Audio", "Input", "Network"}).On(func() {
+				g.writeLog(fmt.Sprintf("Selected log channels: %v", g.logChannels))
+			})
+		})
+		ctx.Header("Choice", true, func() {
+			ctx.SetGridLayout([]int{-1, -1, -1}, nil)
+			ctx.RadioGroup(&g.radio, []string{"Easy", "Normal", "Hard"}).On(func() {
+				g.writeLog(fmt.Sprintf("Selected radio: %d", g.radio))
+			})
+			ctx.SetGridLayout([]int{-1}, nil)
+			ctx.SegmentedControl(&g.segment, []string{"Move", "Rotate", "Scale"}).On(func() {
+				g.writeLog(fmt.Sprintf("Selected segment: %d", g.segment))
+			})
+			ctx.SetGridLayout([]int{-1, -1}, nil)
+			ctx.ToggleButton(&g.toggle, "Toggle").On(func() {
+				g.writeLog(fmt.Sprintf("Toggled: %t", g.toggle))
+			})
+			ctx.Switch(&g.switchOn, "Switch").On(func() {
+				g.writeLog(fmt.Sprintf("Switched: %t", g.switchOn))
+			})
 		})
 
 		ctx.Header("Tree and Text", true, func() {
@@ -129,6 +213,10 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 						"ついで江南尉に補せられたが、性、狷介、自ら恃むところ頗る厚く、" +
 						"賤吏に甘んずるを潔しとしなかった。")
 				})
+				ctx.Header("Rich Text", false, func() {
+					ctx.RichText("[icon=check] Assets loaded. [color=yellow]WARN[/color]: 3 textures are missing. " +
+						"[color=red][b]ERROR[/b][/color]: failed to connect to the server.")
+				})
 			})
 		})
 		ctx.Header("Color", true, func() {
@@ -159,7 +247,7 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 					op.GeoM.Scale(float64(scale), float64(scale))
 					op.PrimaryAlign = text.AlignCenter
 					op.SecondaryAlign = text.AlignCenter
-					debugui.DrawText(screen, txt, op)
+					ctx.DrawText(screen, txt, op)
 				})
 			})
 		})
@@ -172,6 +260,12 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 			ctx.SliderF(&g.num4, 0, 10, 0.1, 2)
 			ctx.Slider(&g.num5, 0, 2, 1)
 		})
+		ctx.Header("Progress", true, func() {
+			ctx.ProgressBar(float64(g.num2)/1000, fmt.Sprintf("%d / 1000", g.num2))
+			ctx.SetGridLayout([]int{-1, 24}, nil)
+			ctx.IndeterminateProgressBar("Loading...")
+			ctx.Spinner()
+		})
 		ctx.Header("Text", true, func() {
 			ctx.TextField(&g.text1)
 			ctx.TextField(&g.text2)
@@ -184,17 +278,26 @@ The Go Gopher by Renee French is licensed under the Creative Commons Attribution
 	})
 }
 
+func (g *Game) sortEnemies() {
+	slices.SortStableFunc(g.enemies, func(a, b enemy) int {
+		var r int
+		switch g.enemySort.Column {
+		case 0:
+			r = strings.Compare(a.name, b.name)
+		case 1:
+			r = a.hp - b.hp
+		}
+		if g.enemySort.Descending {
+			r = -r
+		}
+		return r
+	})
+}
+
 func (g *Game) logWindow(ctx *debugui.Context) {
 	ctx.Window("Log Window", image.Rect(350, 40, 650, 290), func(layout debugui.ContainerLayout) {
 		ctx.SetGridLayout([]int{-1}, []int{-1, 0})
-		ctx.Panel(func(layout debugui.ContainerLayout) {
-			ctx.SetGridLayout([]int{-1}, []int{-1})
-			ctx.Text(g.logBuf)
-			if g.logUpdated {
-				ctx.SetScroll(image.Pt(layout.ScrollOffset.X, layout.ContentSize.Y))
-				g.logUpdated = false
-			}
-		})
+		ctx.Log(&g.logBuffer, nil)
 		ctx.GridCell(func(bounds image.Rectangle) {
 			submit := func() {
 				if g.logSubmitBuf == "" {
@@ -203,7 +306,7 @@ func (g *Game) logWindow(ctx *debugui.Context) {
 				g.writeLog(g.logSubmitBuf)
 				g.logSubmitBuf = ""
 			}
-			ctx.SetGridLayout([]int{-3, -1}, nil)
+			ctx.SetGridLayout([]int{-3, -2, -3}, nil)
 			ctx.TextField(&g.logSubmitBuf).On(func() {
 				if ebiten.IsKeyPressed(ebiten.KeyEnter) {
 					submit()
@@ -216,6 +319,25 @@ func (g *Game) logWindow(ctx *debugui.Context) {
 			ctx.Button("Submit").On(func() {
 				submit()
 			})
+			ctx.ButtonWithShortcut("Clear", "Ctrl+L").On(func() {
+				g.logBuffer.Clear()
+			})
+		})
+	})
+}
+
+func (g *Game) consoleWindow(ctx *debugui.Context) {
+	id := ctx.Window("Console", image.Rect(660, 300, 960, 460), func(layout debugui.ContainerLayout) {
+		ctx.SetGridLayout([]int{-1}, []int{-1})
+		ctx.Console(&g.console)
+	})
+	ctx.WindowShortcut("F1", id)
+}
+
+func (g *Game) varsWindow(ctx *debugui.Context) {
+	ctx.Window("Vars", image.Rect(660, 470, 960, 630), func(layout debugui.ContainerLayout) {
+		ctx.Vars(&debugui.VarsOptions{
+			File: "vars.json",
 		})
 	})
 }
@@ -230,3 +352,27 @@ func (g *Game) buttonWindows(ctx *debugui.Context) {
 		})
 	})
 }
+
+func (g *Game) entityWindow(ctx *debugui.Context) {
+	ctx.WindowWithOptions("Entity List", image.Rect(660, 40, 900, 290), &debugui.WindowOptions{Open: &g.showEntities}, func(layout debugui.ContainerLayout) {
+		ctx.SetGridLayout([]int{-1, -1}, nil)
+		ctx.VirtualList(50000, 0, func(index int) {
+			ctx.Text(fmt.Sprintf("Entity %d", index))
+			ctx.Button("Select").On(func() {
+				g.writeLog(fmt.Sprintf("Selected entity %d", index))
+			})
+		})
+	})
+}
+
+func (g *Game) hudWindow(ctx *debugui.Context) {
+	ctx.WindowWithOptions("HUD", image.Rect(360, 8, 480, 40), &debugui.WindowOptions{
+		NoTitle:  true,
+		NoResize: true,
+		NoScroll: true,
+		NoFrame:  true,
+		AutoSize: true,
+	}, func(layout debugui.ContainerLayout) {
+		ctx.Text(fmt.Sprintf("FPS: %0.2f", ebiten.ActualFPS()))
+	})
+}
@@ -3,6 +3,15 @@
 
 package debugui
 
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
 func IDPartFromCaller() string {
 	pc := caller()
 	return idPartFromCaller(pc)
@@ -11,3 +20,224 @@ func IDPartFromCaller() string {
 func (d *DebugUI) ContainerCounter() int {
 	return len(d.ctx.idToContainer)
 }
+
+func FilterOptions(options []string, query string) []int {
+	return filterOptions(nil, options, query)
+}
+
+func RichTextPlain(markup string) string {
+	_, plain := parseRichText(markup)
+	return plain
+}
+
+func VisualRuns(str string) []string {
+	var runs []string
+	for _, r := range visualRuns(str) {
+		runs = append(runs, str[r.start:r.end])
+	}
+	return runs
+}
+
+func (d *DebugUI) MoveCaret(str string, offset int, dir int) int {
+	return d.ctx.moveCaret(str, offset, dir)
+}
+
+// TestInput simulates the mouse and the keyboard.
+//
+// The pressed mouse button and keys are held until they are released,
+// and their press durations advance at each UpdateWithInput.
+type TestInput struct {
+	cursor        image.Point
+	mouseHeld     bool
+	mouseDuration int
+	keyDurations  map[ebiten.Key]int
+}
+
+// MoveTo moves the cursor.
+func (i *TestInput) MoveTo(x, y int) {
+	i.cursor = image.Pt(x, y)
+}
+
+// Press presses the left mouse button.
+func (i *TestInput) Press() {
+	i.mouseHeld = true
+}
+
+// Release releases the left mouse button.
+func (i *TestInput) Release() {
+	i.mouseHeld = false
+}
+
+// PressKey presses the key.
+func (i *TestInput) PressKey(key ebiten.Key) {
+	if i.keyDurations == nil {
+		i.keyDurations = map[ebiten.Key]int{}
+	}
+	if _, ok := i.keyDurations[key]; !ok {
+		i.keyDurations[key] = 0
+	}
+}
+
+// ReleaseKey releases the key.
+func (i *TestInput) ReleaseKey(key ebiten.Key) {
+	delete(i.keyDurations, key)
+}
+
+func (i *TestInput) advance() {
+	if i.mouseHeld {
+		i.mouseDuration++
+	} else {
+		i.mouseDuration = 0
+	}
+	for key := range i.keyDurations {
+		i.keyDurations[key]++
+	}
+}
+
+func (i *TestInput) cursorPosition() image.Point {
+	return i.cursor
+}
+
+func (i *TestInput) mouseButtonPressDuration() int {
+	return i.mouseDuration
+}
+
+func (i *TestInput) keyPressed(key ebiten.Key) bool {
+	return i.keyPressDuration(key) > 0
+}
+
+func (i *TestInput) keyPressDuration(key ebiten.Key) int {
+	return i.keyDurations[key]
+}
+
+func (i *TestInput) wheel() (float64, float64) {
+	return 0, 0
+}
+
+// handleTextInput doesn't handle any text input, as the IME is not available in tests.
+func (i *TestInput) handleTextInput(field *textinput.Field, x, y int) (bool, error) {
+	return false, nil
+}
+
+// UpdateWithInput advances the input and calls Update with the input.
+func (d *DebugUI) UpdateWithInput(input *TestInput, f func(ctx *Context) error) (InputCapturingState, error) {
+	input.advance()
+	d.ctx.inputSource = input
+	return d.Update(f)
+}
+
+// SetScreenSize sets the screen size, which is usually set by Draw.
+func (d *DebugUI) SetScreenSize(width, height int) {
+	d.ctx.screenWidth, d.ctx.screenHeight = width, height
+}
+
+func (d *DebugUI) TruncateText(str string, width int) (string, bool) {
+	return d.ctx.truncateText(str, width)
+}
+
+func (d *DebugUI) TextWidth(str string) int {
+	return d.ctx.textWidth(str)
+}
+
+func (d *DebugUI) BoldTextWidth(str string) int {
+	return d.ctx.styledTextWidth(str, true)
+}
+
+func (d *DebugUI) LineHeight() int {
+	return d.ctx.lineHeight()
+}
+
+// ScaledFontFace returns the font face to render text of face at the given scale.
+func (d *DebugUI) ScaledFontFace(face text.Face, scale float64) text.Face {
+	f, _ := d.ctx.scaledFontFace(face, scale)
+	return f
+}
+
+func ScaleAlpha(clr color.Color, opacity float64) color.Color {
+	return scaleAlpha(clr, opacity)
+}
+
+func ScaleRect(r image.Rectangle, scale float64) image.Rectangle {
+	return scaleRect(r, scale)
+}
+
+type ResizeEdge = resizeEdge
+
+const (
+	ResizeEdgeLeft   = resizeEdgeLeft
+	ResizeEdgeRight  = resizeEdgeRight
+	ResizeEdgeTop    = resizeEdgeTop
+	ResizeEdgeBottom = resizeEdgeBottom
+)
+
+// ConstrainWindowBounds returns the bounds constrained for a window with the given size limits.
+func (d *DebugUI) ConstrainWindowBounds(bounds image.Rectangle, edges ResizeEdge, minSize, maxSize image.Point, aspectRatio float64) image.Rectangle {
+	cnt := &container{
+		minSize:     minSize,
+		maxSize:     maxSize,
+		aspectRatio: aspectRatio,
+	}
+	return d.ctx.constrainWindowBounds(cnt, bounds, edges)
+}
+
+// CurrentBounds returns the bounds of the last widget.
+func (c *Context) CurrentBounds() image.Rectangle {
+	return c.currentBounds
+}
+
+// TextBounds returns the bounds of the last text equal to str drawn in the last Update.
+func (d *DebugUI) TextBounds(str string) (image.Rectangle, bool) {
+	var bounds image.Rectangle
+	var found bool
+	for cmd := range d.ctx.commands() {
+		if cmd.typ != commandText || cmd.text.str != str {
+			continue
+		}
+		pos := cmd.text.pos
+		bounds = image.Rect(pos.X, pos.Y, pos.X+d.ctx.styledTextWidth(str, cmd.text.bold), pos.Y+d.ctx.lineHeight())
+		found = true
+	}
+	return bounds, found
+}
+
+// IconBounds returns the bounds of the icons drawn in the last Update.
+func (d *DebugUI) IconBounds() []image.Rectangle {
+	var bounds []image.Rectangle
+	for cmd := range d.ctx.commands() {
+		if cmd.typ == commandIcon {
+			bounds = append(bounds, cmd.icon.rect)
+		}
+	}
+	return bounds
+}
+
+const (
+	ColorText        = colorText
+	ColorButtonFocus = colorButtonFocus
+)
+
+// RectBounds returns the bounds of the rectangles filled with the style color of colorID in the last Update.
+func (d *DebugUI) RectBounds(colorID int) []image.Rectangle {
+	var bounds []image.Rectangle
+	for cmd := range d.ctx.commands() {
+		if cmd.typ == commandRect && cmd.rect.color == color.Color(d.ctx.style().colors[colorID]) {
+			bounds = append(bounds, cmd.rect.rect)
+		}
+	}
+	return bounds
+}
+
+// SetVarsResetHook sets the function called right after the Reset button of Vars is laid out.
+func SetVarsResetHook(f func(ctx *Context)) {
+	testHookVarsReset = f
+}
+
+// UnregisterVar removes the var from the global registry.
+//
+// There is no public way to unregister a var, but tests need it to keep the registry clean.
+func UnregisterVar(path string) {
+	r := &theVarRegistry
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.vars, path)
+}
@@ -7,6 +7,7 @@ require (
 	github.com/hajimehoshi/bitmapfont/v4 v4.1.1
 	github.com/hajimehoshi/ebiten/v2 v2.9.9
 	github.com/kisielk/errcheck v1.10.0
+	golang.org/x/text v0.29.0
 	golang.org/x/tools v0.44.0
 )
 
@@ -21,5 +22,4 @@ require (
 	golang.org/x/mod v0.35.0 // indirect
 	golang.org/x/sync v0.20.0 // indirect
 	golang.org/x/sys v0.43.0 // indirect
-	golang.org/x/text v0.29.0 // indirect
 )
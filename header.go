@@ -87,7 +87,7 @@ func (c *Context) header(label string, isTreeNode bool, opt option, id widgetID,
 			c.style().colors[colorText],
 		)
 		bounds.Min.X += bounds.Dy() - c.style().padding
-		c.drawWidgetText(label, bounds, colorText, 0)
+		c.drawTruncatedWidgetText(label, bounds, colorText, 0)
 	})
 	if err != nil {
 		return err
@@ -8,10 +8,51 @@ import (
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
+// inputSource provides the state of the mouse and the keyboard.
+//
+// The default input source is Ebitengine's input. Tests replace the input source to simulate the input.
+type inputSource interface {
+	cursorPosition() image.Point
+	mouseButtonPressDuration() int
+	keyPressed(key ebiten.Key) bool
+	keyPressDuration(key ebiten.Key) int
+	wheel() (float64, float64)
+	handleTextInput(field *textinput.Field, x, y int) (bool, error)
+}
+
+type ebitenInput struct{}
+
+func (ebitenInput) cursorPosition() image.Point {
+	return image.Pt(ebiten.CursorPosition())
+}
+
+func (ebitenInput) mouseButtonPressDuration() int {
+	return inpututil.MouseButtonPressDuration(ebiten.MouseButtonLeft)
+}
+
+func (ebitenInput) keyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (ebitenInput) keyPressDuration(key ebiten.Key) int {
+	return inpututil.KeyPressDuration(key)
+}
+
+func (ebitenInput) wheel() (float64, float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInput) handleTextInput(field *textinput.Field, x, y int) (bool, error) {
+	return field.HandleInput(x, y)
+}
+
 type pointing struct {
+	input inputSource
+
 	justPressedTouchIDs []ebiten.TouchID
 	touchIDs            []ebiten.TouchID
 	hasPrimaryTouchID   bool
@@ -19,7 +60,8 @@ type pointing struct {
 	duration            int
 }
 
-func (p *pointing) update() {
+func (p *pointing) update(input inputSource) {
+	p.input = input
 	p.justPressedTouchIDs = inpututil.AppendJustPressedTouchIDs(p.justPressedTouchIDs[:0])
 	p.touchIDs = ebiten.AppendTouchIDs(p.touchIDs[:0])
 
@@ -49,29 +91,61 @@ func (p *pointing) position() image.Point {
 	if p.isTouchActive() {
 		return image.Pt(ebiten.TouchPosition(p.primaryTouchID))
 	}
-	return image.Pt(ebiten.CursorPosition())
+	return p.input.cursorPosition()
 }
 
 func (p *pointing) pressed() bool {
 	if p.isTouchActive() {
 		return true
 	}
-	return ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return p.input.mouseButtonPressDuration() > 0
 }
 
 func (p *pointing) justPressed() bool {
 	if p.isTouchActive() {
 		return slices.Contains(p.justPressedTouchIDs, p.primaryTouchID)
 	}
-	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	return p.input.mouseButtonPressDuration() == 1
 }
 
 func (p *pointing) repeated() bool {
 	return repeated(p.duration)
 }
 
-func keyRepeated(key ebiten.Key) bool {
-	return repeated(inpututil.KeyPressDuration(key))
+func (c *Context) input() inputSource {
+	if c.inputSource != nil {
+		return c.inputSource
+	}
+	return ebitenInput{}
+}
+
+func (c *Context) isKeyPressed(key ebiten.Key) bool {
+	return c.input().keyPressed(key)
+}
+
+func (c *Context) isKeyJustPressed(key ebiten.Key) bool {
+	if c.keyConsumed(key) {
+		return false
+	}
+	return c.input().keyPressDuration(key) == 1
+}
+
+func (c *Context) keyRepeated(key ebiten.Key) bool {
+	if c.keyConsumed(key) {
+		return false
+	}
+	return repeated(c.input().keyPressDuration(key))
+}
+
+// consumeKey marks the key as handled in the current tick.
+// A consumed key is not just pressed nor repeated for the rest of the tick,
+// so that e.g. the Escape key closing a combo box list doesn't close the modal window including it.
+func (c *Context) consumeKey(key ebiten.Key) {
+	c.consumedKeys = append(c.consumedKeys, key)
+}
+
+func (c *Context) keyConsumed(key ebiten.Key) bool {
+	return slices.Contains(c.consumedKeys, key)
 }
 
 func repeated(duration int) bool {
@@ -6,6 +6,7 @@ package debugui
 import (
 	"errors"
 	"image"
+	"slices"
 )
 
 type layout struct {
@@ -191,3 +192,61 @@ func (c *Context) layoutNext() (image.Rectangle, error) {
 
 	return r, nil
 }
+
+// virtualRows lays out count rows with the given row height, and calls f only for the rows visible in the current clip rect.
+// Each call of f must lay out one row of the current grid layout.
+//
+// The layout advances as if all the rows were laid out, so the content size of the container is correct.
+// If rowHeight is 0 or negative, the default height is used.
+func (c *Context) virtualRows(count int, rowHeight int, f func(index int)) error {
+	l, err := c.layout()
+	if err != nil {
+		return err
+	}
+	if rowHeight <= 0 {
+		rowHeight = c.style().defaultHeight
+	}
+	widths := slices.Clone(l.widths)
+	heights := slices.Clone(l.heights)
+	rowHeights := []int{rowHeight}
+
+	// Start a new row.
+	if l.itemIndex > 0 {
+		l.position = image.Pt(l.indent, l.nextRowY)
+		l.itemIndex = 0
+	}
+	top := l.nextRowY
+	stride := rowHeight + c.style().spacing
+
+	// Calculate the visible range in the layout coordinate.
+	clip := c.clipRect()
+	visibleTop := clip.Min.Y - l.body.Min.Y - top
+	visibleBottom := clip.Max.Y - l.body.Min.Y - top
+	first := clamp(visibleTop/stride, 0, count)
+	last := first
+	if visibleBottom > 0 {
+		last = clamp((visibleBottom+stride-1)/stride, first, count)
+	}
+
+	for i := first; i < last; i++ {
+		l, err := c.layout()
+		if err != nil {
+			return err
+		}
+		l.nextRowY = top + i*stride
+		if err := c.setGridLayout(widths, rowHeights); err != nil {
+			return err
+		}
+		f(i)
+	}
+
+	l, err = c.layout()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		l.nextRowY = top + count*stride
+		l.max.Y = max(l.max.Y, l.body.Min.Y+l.nextRowY-c.style().spacing)
+	}
+	return c.setGridLayout(widths, heights)
+}
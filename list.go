@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+)
+
+// VirtualList lays out count rows, and calls f only for the rows visible in the current container.
+//
+// rowHeight is the height of each row in pixels. If rowHeight is 0, the default height is used.
+// Each call of f must lay out one row of the current grid layout,
+// e.g. one widget for a one-column layout or two widgets for a two-column layout.
+//
+// The content size of the container is calculated as if all the rows were laid out,
+// so the scroll bars of the container work correctly.
+// VirtualList is useful to show a large number of rows in a scrollable container like a window or a panel.
+//
+// VirtualList creates a unique ID scope for each row like [Loop].
+func (c *Context) VirtualList(count int, rowHeight int, f func(index int)) {
+	pc := caller()
+	c.idStack = c.idStack.push(idPartFromCaller(pc))
+	defer func() {
+		c.idStack = c.idStack.pop()
+	}()
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if err := c.virtualRows(count, rowHeight, func(index int) {
+			c.idStack = c.idStack.push(idPartFromInt(index))
+			defer func() {
+				c.idStack = c.idStack.pop()
+			}()
+			f(index)
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// ListBox creates a list box widget to select one item from the visible list.
+//
+// selectedIndex is a pointer to the currently selected item index (0-based).
+// If *selectedIndex is out of range, no item is selected.
+//
+// The list box occupies one grid cell. Use [SetGridLayout] to specify the size of the list box.
+// The list is scrolled when the items don't fit in the list box.
+//
+// ListBox returns an EventHandler to handle selection change events.
+// A returned EventHandler is never nil.
+//
+// A ListBox widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) ListBox(selectedIndex *int, items []string) WidgetEventHandler {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		var e EventHandler
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			e, err = c.listBox(items, func(index int) bool {
+				return index == *selectedIndex
+			}, func(index int, anchor *int) bool {
+				if *selectedIndex == index {
+					return false
+				}
+				*selectedIndex = index
+				return true
+			}, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+// MultiSelectListBox creates a list box widget to select multiple items from the visible list.
+//
+// selection reports whether each item is selected. The length of selection must be the same as items.
+//
+// Clicking an item selects only the item.
+// Clicking an item with the Control key adds the item to or removes the item from the selection.
+// Clicking an item with the Shift key selects the range of items from the last clicked item.
+//
+// The list box occupies one grid cell. Use [SetGridLayout] to specify the size of the list box.
+// The list is scrolled when the items don't fit in the list box.
+//
+// MultiSelectListBox returns an EventHandler to handle selection change events.
+// A returned EventHandler is never nil.
+//
+// A MultiSelectListBox widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) MultiSelectListBox(selection []bool, items []string) WidgetEventHandler {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		if len(selection) != len(items) {
+			return nil, fmt.Errorf("debugui: the length of selection (%d) must be the same as the length of items (%d)", len(selection), len(items))
+		}
+		var e EventHandler
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			e, err = c.listBox(items, func(index int) bool {
+				return selection[index]
+			}, func(index int, anchor *int) bool {
+				var indices []int
+				for i, selected := range selection {
+					if selected {
+						indices = append(indices, i)
+					}
+				}
+				if !c.selectRow(&indices, index, true, anchor) {
+					return false
+				}
+				clear(selection)
+				for _, i := range indices {
+					selection[i] = true
+				}
+				return true
+			}, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+type listBoxState struct {
+	// selectionAnchor is the index of the item where a range selection starts.
+	selectionAnchor int
+}
+
+func (c *Context) listBox(items []string, selected func(index int) bool, click func(index int, anchor *int) bool, id widgetID) (EventHandler, error) {
+	cnt := c.container(id, 0)
+	state := widgetState[listBoxState](cnt, id)
+	bounds, err := c.layoutNext()
+	if err != nil {
+		return nil, err
+	}
+
+	var e EventHandler
+	itemID := id.push(idPartFromString("item"))
+	if err := c.doPanelWithBounds(cnt, bounds, 0, func(layout ContainerLayout) {
+		c.SetGridLayout([]int{-1}, nil)
+		if err := c.virtualRows(len(items), 0, func(index int) {
+			rowID := itemID.push(idPartFromInt(index))
+			_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+				return c.widget(rowID, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+					if c.pointing.justPressed() && c.focus == rowID && click(index, &state.selectionAnchor) {
+						e = &eventHandler{}
+					}
+					return nil
+				}, func(bounds image.Rectangle) {
+					switch {
+					case selected(index):
+						c.drawRect(bounds, c.style().colors[colorButtonFocus])
+					case c.hover == rowID:
+						c.drawRect(bounds, c.style().colors[colorButtonHover])
+					}
+					c.drawWidgetText(items[index], bounds, colorText, 0)
+				})
+			})
+		}); err != nil && c.err == nil {
+			c.err = err
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
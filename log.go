@@ -0,0 +1,515 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel represents the severity level of a log entry.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+const logLevelCount = 4
+
+// String returns the name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return "LogLevel(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+func (l LogLevel) color(style *style) color.Color {
+	switch l {
+	case LogLevelDebug:
+		return richTextColors["gray"]
+	case LogLevelWarn:
+		return richTextColors["yellow"]
+	case LogLevelError:
+		return richTextColors["red"]
+	default:
+		return style.colors[colorText]
+	}
+}
+
+// LogEntry represents an entry of a LogBuffer.
+type LogEntry struct {
+	// Time is the time when the entry was added.
+	Time time.Time
+
+	// Level is the severity level of the entry.
+	Level LogLevel
+
+	// Channel is the name of the channel, such as "render" or "network".
+	// Channel can be empty.
+	Channel string
+
+	// Message is the message of the entry.
+	Message string
+}
+
+const (
+	defaultLogBufferCapacity = 1000
+	logTimeFormat            = "15:04:05.000"
+)
+
+// String returns the entry formatted as a line, such as "12:34:56.789 WARN [net] timeout".
+func (e *LogEntry) String() string {
+	var b strings.Builder
+	b.WriteString(e.Time.Format(logTimeFormat))
+	b.WriteString(" ")
+	b.WriteString(e.Level.String())
+	if e.Channel != "" {
+		b.WriteString(" [")
+		b.WriteString(e.Channel)
+		b.WriteString("]")
+	}
+	b.WriteString(" ")
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+type logRecord struct {
+	LogEntry
+
+	// seq is the sequence number of the entry in the buffer.
+	seq int
+}
+
+// LogBuffer is a bounded ring buffer of log entries.
+//
+// When the number of entries exceeds the capacity, the oldest entry is discarded.
+// LogBuffer is safe for concurrent use by multiple goroutines.
+//
+// The zero value of LogBuffer is ready to use with the default capacity 1000.
+type LogBuffer struct {
+	capacity int
+
+	// records is the ring buffer. head is the index of the oldest record.
+	records []logRecord
+	head    int
+
+	// nextSeq is the sequence number of the next record.
+	nextSeq int
+
+	// generation is incremented when the buffer is cleared.
+	generation int
+
+	// channels is the sorted names of the channels added so far.
+	channels []string
+
+	m sync.Mutex
+}
+
+// NewLogBuffer creates a new LogBuffer with the given capacity.
+//
+// If capacity is 0 or negative, the default capacity 1000 is used.
+func NewLogBuffer(capacity int) *LogBuffer {
+	return &LogBuffer{
+		capacity: capacity,
+	}
+}
+
+// Add adds an entry with the current time to the buffer.
+func (b *LogBuffer) Add(level LogLevel, channel string, message string) {
+	b.add(LogEntry{
+		Time:    time.Now(),
+		Level:   level,
+		Channel: channel,
+		Message: message,
+	})
+}
+
+// Addf adds an entry with the current time and the formatted message to the buffer.
+func (b *LogBuffer) Addf(level LogLevel, channel string, format string, args ...any) {
+	b.Add(level, channel, fmt.Sprintf(format, args...))
+}
+
+func (b *LogBuffer) add(entry LogEntry) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	capacity := b.capacity
+	if capacity <= 0 {
+		capacity = defaultLogBufferCapacity
+	}
+	r := logRecord{
+		LogEntry: entry,
+		seq:      b.nextSeq,
+	}
+	b.nextSeq++
+	if len(b.records) < capacity {
+		b.records = append(b.records, r)
+	} else {
+		b.records[b.head] = r
+		b.head = (b.head + 1) % len(b.records)
+	}
+
+	if idx, found := slices.BinarySearch(b.channels, entry.Channel); !found {
+		b.channels = slices.Insert(b.channels, idx, entry.Channel)
+	}
+}
+
+// Len returns the number of entries in the buffer.
+func (b *LogBuffer) Len() int {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return len(b.records)
+}
+
+// Entries returns a copy of the entries in the buffer from the oldest to the newest.
+func (b *LogBuffer) Entries() []LogEntry {
+	b.m.Lock()
+	defer b.m.Unlock()
+	entries := make([]LogEntry, len(b.records))
+	for i := range entries {
+		entries[i] = b.record(i).LogEntry
+	}
+	return entries
+}
+
+// Clear removes all the entries from the buffer.
+func (b *LogBuffer) Clear() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.records = b.records[:0]
+	b.head = 0
+	b.channels = b.channels[:0]
+	b.generation++
+}
+
+// record returns the i-th oldest record. The caller must hold the lock.
+func (b *LogBuffer) record(i int) *logRecord {
+	return &b.records[(b.head+i)%len(b.records)]
+}
+
+// channelNames returns a copy of the channel names.
+func (b *LogBuffer) channelNames() []string {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return slices.Clone(b.channels)
+}
+
+// logState is the state of a Log widget.
+type logState struct {
+	buffer     *LogBuffer
+	generation int
+
+	// nextSeq is the sequence number of the next record to be filtered.
+	nextSeq int
+
+	// rows is the records shown in the widget.
+	rows []logRecord
+
+	hiddenLevels   [logLevelCount]bool
+	hiddenChannels map[string]struct{}
+	search         string
+	appliedSearch  string
+	filterChanged  bool
+
+	// selection is the sequence numbers of the selected records in ascending order.
+	selection []int
+
+	// selectionAnchor is the sequence number of the record where a range selection starts.
+	selectionAnchor int
+}
+
+// sync updates the shown rows with the new records in the buffer.
+func (s *logState) sync(buffer *LogBuffer) {
+	buffer.m.Lock()
+	defer buffer.m.Unlock()
+
+	if s.buffer != buffer || s.generation != buffer.generation {
+		s.selection = s.selection[:0]
+	}
+	if s.buffer != buffer || s.generation != buffer.generation || s.filterChanged || s.appliedSearch != s.search {
+		s.buffer = buffer
+		s.generation = buffer.generation
+		s.nextSeq = 0
+		s.rows = s.rows[:0]
+		s.filterChanged = false
+		s.appliedSearch = s.search
+	}
+
+	// Remove the records discarded from the buffer.
+	oldest := buffer.nextSeq - len(buffer.records)
+	s.rows = s.rows[sort.Search(len(s.rows), func(i int) bool {
+		return s.rows[i].seq >= oldest
+	}):]
+	s.selection = s.selection[sort.SearchInts(s.selection, oldest):]
+
+	search := strings.ToLower(s.appliedSearch)
+	for i := max(s.nextSeq, oldest) - oldest; i < len(buffer.records); i++ {
+		r := buffer.record(i)
+		if s.hiddenLevels[clamp(int(r.Level), 0, logLevelCount-1)] {
+			continue
+		}
+		if _, ok := s.hiddenChannels[r.Channel]; ok {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(r.Message), search) && !strings.Contains(strings.ToLower(r.Channel), search) {
+			continue
+		}
+		s.rows = append(s.rows, *r)
+	}
+	s.nextSeq = buffer.nextSeq
+}
+
+// selectedText returns the text of the selected rows.
+// If no row is selected, selectedText returns the text of all the shown rows.
+func (s *logState) selectedText() string {
+	var b strings.Builder
+	for _, r := range s.rows {
+		if len(s.selection) > 0 {
+			if _, found := slices.BinarySearch(s.selection, r.seq); !found {
+				continue
+			}
+		}
+		b.WriteString(r.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// LogOptions represents options for a log widget.
+type LogOptions struct {
+	// Copy is called with the text of the selected lines when the Copy button is clicked.
+	// If no line is selected, the text of all the shown lines is passed.
+	//
+	// debugui doesn't access the clipboard. Write the text to the clipboard in Copy.
+	// If Copy is nil, the Copy button is not shown.
+	Copy func(text string)
+}
+
+// Log creates a log widget showing the entries of the given buffer.
+//
+// The log occupies one grid cell. Use [SetGridLayout] to specify the size of the log.
+// The toolbar has toggle buttons to filter the entries by levels and channels, and a text field to search the entries.
+// The log scrolls to the bottom automatically when new entries are added.
+// Scrolling up pauses the automatic scroll, and scrolling to the bottom resumes it.
+// Lines can be selected by clicking, with the Shift and Control keys for multiple lines.
+//
+// options can be nil.
+//
+// A Log widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Log(buffer *LogBuffer, options *LogOptions) {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			err = c.log(buffer, options, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+func (c *Context) log(buffer *LogBuffer, options *LogOptions, id widgetID) error {
+	if options == nil {
+		options = &LogOptions{}
+	}
+
+	cnt := c.container(id, 0)
+	s := widgetState[logState](cnt, id)
+
+	var err error
+	c.GridCell(func(bounds image.Rectangle) {
+		channels := buffer.channelNames()
+		if err = c.logToolbar(s, channels, bounds, options, id); err != nil {
+			return
+		}
+		s.sync(buffer)
+
+		// The body takes the remaining space of the cell.
+		l, err2 := c.layout()
+		if err2 != nil {
+			err = err2
+			return
+		}
+		if err = c.setGridLayout([]int{-1}, []int{max(bounds.Dy()-l.nextRowY, c.style().defaultHeight)}); err != nil {
+			return
+		}
+		body, err2 := c.layoutNext()
+		if err2 != nil {
+			err = err2
+			return
+		}
+		err = c.logBody(s, channels, body, cnt, id)
+	})
+	return err
+}
+
+func (c *Context) logToolbar(s *logState, channels []string, bounds image.Rectangle, options *LogOptions, id widgetID) error {
+	levelWidth := c.lineHeight() + c.style().padding*2
+	widths := []int{levelWidth, levelWidth, levelWidth, levelWidth, -1}
+	if options.Copy != nil {
+		widths = append(widths, 0)
+	}
+	if err := c.setGridLayout(widths, nil); err != nil {
+		return err
+	}
+
+	levelID := id.push(idPartFromString("level"))
+	for i := range logLevelCount {
+		level := LogLevel(i)
+		shown := !s.hiddenLevels[i]
+		if _, err := c.toggleButton(&shown, level.String()[:1], optionAlignCenter, levelID.push(idPartFromInt(i))); err != nil {
+			return err
+		}
+		c.setTooltip(c.currentBounds, level.String())
+		if shown == s.hiddenLevels[i] {
+			s.hiddenLevels[i] = !shown
+			s.filterChanged = true
+		}
+	}
+
+	if _, err := c.textField(&s.search, id.push(idPartFromString("search")), 0); err != nil {
+		return err
+	}
+	c.setTooltip(c.currentBounds, "Search")
+
+	if options.Copy != nil {
+		e, err := c.button("Copy", optionAlignCenter, id.push(idPartFromString("copy")))
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			options.Copy(s.selectedText())
+		}
+	}
+
+	if len(channels) == 0 || (len(channels) == 1 && channels[0] == "") {
+		return nil
+	}
+
+	// Lay out the channel filters in as many columns as the width allows.
+	columns := max(1, bounds.Dx()/(c.style().defaultWidth+c.style().padding*2))
+	widths = make([]int, min(columns, len(channels)))
+	for i := range widths {
+		widths[i] = -1
+	}
+	if err := c.setGridLayout(widths, nil); err != nil {
+		return err
+	}
+	channelID := id.push(idPartFromString("channel"))
+	for _, channel := range channels {
+		_, hidden := s.hiddenChannels[channel]
+		shown := !hidden
+		name := channel
+		if name == "" {
+			name = "(none)"
+		}
+		if _, err := c.toggleButton(&shown, name, optionAlignCenter, channelID.push(idPartFromString(channel))); err != nil {
+			return err
+		}
+		if shown == hidden {
+			continue
+		}
+		if shown {
+			delete(s.hiddenChannels, channel)
+		} else {
+			if s.hiddenChannels == nil {
+				s.hiddenChannels = map[string]struct{}{}
+			}
+			s.hiddenChannels[channel] = struct{}{}
+		}
+		s.filterChanged = true
+	}
+	return nil
+}
+
+func (c *Context) logBody(s *logState, channels []string, bounds image.Rectangle, cnt *container, id widgetID) error {
+	timeWidth := c.textWidth(logTimeFormat)
+	var levelWidth int
+	for i := range logLevelCount {
+		levelWidth = max(levelWidth, c.textWidth(LogLevel(i).String()))
+	}
+	var channelWidth int
+	for _, channel := range channels {
+		if channel == "" {
+			continue
+		}
+		channelWidth = max(channelWidth, c.textWidth("["+channel+"]"))
+	}
+	spacing := c.style().spacing
+
+	rowHeight := c.lineHeight()
+	rowID := id.push(idPartFromString("row"))
+	return c.doPanelWithBounds(cnt, bounds, 0, func(layout ContainerLayout) {
+		// Keep scrolling to the bottom unless the user scrolls up.
+		maxScroll := layout.ContentSize.Y + c.style().padding*2 - layout.BodyBounds.Dy()
+		follow := layout.ScrollOffset.Y >= maxScroll
+
+		c.SetGridLayout([]int{-1}, nil)
+		if err := c.virtualRows(len(s.rows), rowHeight, func(row int) {
+			r := &s.rows[row]
+			currentRowID := rowID.push(idPartFromInt(r.seq))
+			_, _ = c.widget(currentRowID, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+				if c.pointing.justPressed() && c.focus == currentRowID {
+					c.selectRow(&s.selection, r.seq, true, &s.selectionAnchor)
+				}
+				return nil
+			}, func(bounds image.Rectangle) {
+				if _, found := slices.BinarySearch(s.selection, r.seq); found {
+					c.drawRect(bounds, c.style().colors[colorButtonFocus])
+				} else if c.hover == currentRowID {
+					c.drawRect(bounds, c.style().colors[colorButtonHover])
+				}
+				x := bounds.Min.X + c.style().padding
+				y := bounds.Min.Y + (bounds.Dy()-c.lineHeight())/2
+				c.drawText(r.Time.Format(logTimeFormat), image.Pt(x, y), richTextColors["gray"])
+				x += timeWidth + spacing
+				c.drawText(r.Level.String(), image.Pt(x, y), r.Level.color(c.style()))
+				x += levelWidth + spacing
+				if channelWidth > 0 {
+					if r.Channel != "" {
+						c.drawText("["+r.Channel+"]", image.Pt(x, y), c.style().colors[colorText])
+					}
+					x += channelWidth + spacing
+				}
+				c.drawText(strings.ReplaceAll(r.Message, "\n", " "), image.Pt(x, y), c.style().colors[colorText])
+			})
+		}); err != nil && c.err == nil {
+			c.err = err
+		}
+
+		if follow {
+			l, err := c.layout()
+			if err != nil {
+				c.err = err
+				return
+			}
+			contentHeight := l.max.Y - l.body.Min.Y
+			c.SetScroll(image.Pt(layout.ScrollOffset.X, max(0, contentHeight+c.style().padding*2-layout.BodyBounds.Dy())))
+		}
+	})
+}
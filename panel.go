@@ -3,6 +3,8 @@
 
 package debugui
 
+import "image"
+
 // Panel creates a new panel with the contents defined by the function f.
 // Panel can have scroll bars, and the contents of the panel can be scrolled.
 func (c *Context) Panel(f func(layout ContainerLayout)) {
@@ -29,7 +31,11 @@ func (c *Context) doPanel(opt option, id widgetID, f func(layout ContainerLayout
 	if err != nil {
 		return err
 	}
-	cnt.layout.Bounds = l
+	return c.doPanelWithBounds(cnt, l, opt, f)
+}
+
+func (c *Context) doPanelWithBounds(cnt *container, bounds image.Rectangle, opt option, f func(layout ContainerLayout)) (err error) {
+	cnt.layout.Bounds = bounds
 	if (^opt & optionNoFrame) != 0 {
 		c.drawFrame(cnt.layout.Bounds, colorPanelBG)
 	}
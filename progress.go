@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ProgressBar creates a progress bar widget with the given fraction and text.
+//
+// fraction is the progress in the range of [0, 1]. fraction out of the range is clamped.
+// text is drawn over the bar. If text is empty, no text is drawn.
+func (c *Context) ProgressBar(fraction float64, text string) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+			c.drawFrame(bounds, colorBase)
+			fraction = clamp(fraction, 0, 1)
+			if math.IsNaN(fraction) {
+				fraction = 0
+			}
+			bar := bounds
+			bar.Max.X = bar.Min.X + int(float64(bounds.Dx())*fraction)
+			c.drawRect(bar, c.style().colors[colorButtonFocus])
+			if text != "" {
+				c.drawWidgetText(text, bounds, colorText, optionAlignCenter)
+			}
+		})
+	})
+}
+
+// IndeterminateProgressBar creates a progress bar widget for an operation whose progress is unknown.
+//
+// text is drawn over the bar. If text is empty, no text is drawn.
+func (c *Context) IndeterminateProgressBar(text string) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+			c.drawFrame(bounds, colorBase)
+
+			// Move the bar back and forth.
+			const halfPeriod = 60
+			t := c.tick % (halfPeriod * 2)
+			if t > halfPeriod {
+				t = halfPeriod*2 - t
+			}
+			w := bounds.Dx() / 4
+			x := (bounds.Dx() - w) * t / halfPeriod
+			bar := image.Rect(bounds.Min.X+x, bounds.Min.Y, bounds.Min.X+x+w, bounds.Max.Y)
+			c.drawRect(bar, c.style().colors[colorButtonFocus])
+			if text != "" {
+				c.drawWidgetText(text, bounds, colorText, optionAlignCenter)
+			}
+		})
+	})
+}
+
+// Spinner creates a small animated indicator for an ongoing operation.
+//
+// The indicator is drawn at the center of the grid cell.
+func (c *Context) Spinner() {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+			const (
+				dotCount    = 8
+				ticksPerDot = 6
+				dotSize     = 2
+			)
+			h := c.lineHeight()
+			center := image.Pt(bounds.Min.X+bounds.Dx()/2, bounds.Min.Y+bounds.Dy()/2)
+			radius := float64(h-dotSize) / 2
+			head := (c.tick / ticksPerDot) % dotCount
+			clr := c.style().colors[colorText]
+			for i := range dotCount {
+				// The dots fade out behind the head.
+				a := float64(dotCount-(head-i+dotCount)%dotCount) / dotCount
+				theta := 2 * math.Pi * float64(i) / dotCount
+				x := center.X + int(math.Round(radius*math.Cos(theta))) - dotSize/2
+				y := center.Y + int(math.Round(radius*math.Sin(theta))) - dotSize/2
+				c.drawRect(image.Rect(x, y, x+dotSize, y+dotSize), color.RGBA{
+					R: uint8(float64(clr.R) * a),
+					G: uint8(float64(clr.G) * a),
+					B: uint8(float64(clr.B) * a),
+					A: uint8(float64(clr.A) * a),
+				})
+			}
+		})
+	})
+}
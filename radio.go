@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import "image"
+
+// RadioButton creates a radio button with the given index and text label.
+//
+// The radio button is selected when *value equals to index.
+// When the radio button is clicked, *value is set to index.
+//
+// RadioButton returns an EventHandler to handle value change events.
+// A returned EventHandler is never nil.
+//
+// A RadioButton widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) RadioButton(value *int, index int, label string) WidgetEventHandler {
+	pc := caller()
+	id := c.idStack.push(idPartFromCaller(pc))
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.radioButton(value, index, label, id)
+	})
+}
+
+// RadioGroup creates radio buttons for each label.
+// Each radio button is placed in its own grid cell.
+//
+// *value is the index of the selected label.
+//
+// RadioGroup returns an EventHandler to handle value change events.
+// A returned EventHandler is never nil.
+//
+// A RadioGroup widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) RadioGroup(value *int, labels []string) WidgetEventHandler {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.radioGroup(value, labels, idPart)
+	})
+}
+
+// SegmentedControl creates a row of toggle buttons sharing one selection.
+// All the buttons are placed in one grid cell.
+//
+// *value is the index of the selected label.
+//
+// SegmentedControl returns an EventHandler to handle value change events.
+// A returned EventHandler is never nil.
+//
+// A SegmentedControl widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) SegmentedControl(value *int, labels []string) WidgetEventHandler {
+	pc := caller()
+	id := c.idStack.push(idPartFromCaller(pc))
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.segmentedControl(value, labels, id)
+	})
+}
+
+func (c *Context) radioButton(value *int, index int, label string, id widgetID) (EventHandler, error) {
+	return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		var e EventHandler
+		if c.pointing.justPressed() && c.focus == id && *value != index {
+			*value = index
+			e = &eventHandler{}
+		}
+		return e
+	}, func(bounds image.Rectangle) {
+		c.drawCheckbox(id, bounds, *value == index, label)
+	})
+}
+
+func (c *Context) radioGroup(value *int, labels []string, idPart string) (EventHandler, error) {
+	var e EventHandler
+	var err error
+	c.idScopeFromIDPart(idPart, func(id widgetID) {
+		for i, label := range labels {
+			e1, err1 := c.radioButton(value, i, label, id.push(idPartFromInt(i)))
+			if err1 != nil {
+				err = err1
+				return
+			}
+			if e1 != nil {
+				e = e1
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (c *Context) segmentedControl(value *int, labels []string, id widgetID) (EventHandler, error) {
+	last := *value
+
+	segmentBounds := func(bounds image.Rectangle, index int) image.Rectangle {
+		r := bounds
+		r.Min.X = bounds.Min.X + bounds.Dx()*index/len(labels)
+		r.Max.X = bounds.Min.X + bounds.Dx()*(index+1)/len(labels)
+		// Leave a gap between segments.
+		if index < len(labels)-1 {
+			r.Max.X--
+		}
+		return r
+	}
+
+	return c.widget(id, optionNoInteract, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		for i := range labels {
+			segmentID := id.push(idPartFromInt(i))
+			c.handleInputForWidget(segmentID, segmentBounds(bounds, i), 0)
+			if c.pointing.justPressed() && c.focus == segmentID {
+				*value = i
+			}
+		}
+		if *value != last {
+			return &eventHandler{}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		for i, label := range labels {
+			segmentID := id.push(idPartFromInt(i))
+			r := segmentBounds(bounds, i)
+			if *value == i {
+				c.drawFrame(r, colorButtonFocus)
+			} else {
+				c.drawWidgetFrame(segmentID, r, colorButton, 0)
+			}
+			c.drawWidgetText(label, r, colorText, optionAlignCenter)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"math"
+)
+
+type resizeEdge int
+
+const (
+	resizeEdgeLeft resizeEdge = 1 << iota
+	resizeEdgeRight
+	resizeEdgeTop
+	resizeEdgeBottom
+)
+
+const (
+	defaultWindowMinWidth  = 96
+	defaultWindowMinHeight = 64
+)
+
+// resizable reports whether the root container can be resized by the user.
+func (c *container) resizable() bool {
+	return (c.opt&optionNoResize) == 0 && !c.docked && !c.collapsed
+}
+
+// resizeHitBounds returns the bounds of the root container including the resize handles outside the window.
+func (c *Context) resizeHitBounds(cnt *container) image.Rectangle {
+	if !cnt.resizable() {
+		return cnt.layout.Bounds
+	}
+	return cnt.layout.Bounds.Inset(-c.style().spacing)
+}
+
+// resizeHandleRootContainer returns the root container whose resize handles or itself are at the pointing position.
+//
+// Unlike hoveringRootContainer, the resize handles outside the windows are taken into account.
+// The pointing device on the handles doesn't hover the window, but hides the windows behind the handles.
+func (c *Context) resizeHandleRootContainer() *container {
+	return c.rootContainerAt(c.resizeHitBounds)
+}
+
+// resizeHandles handles the resize handles at the edges and the corners of the window.
+//
+// The handles at the edges are outside the window, so that they don't overlap the title bar, the buttons in it,
+// the scroll bars and the other widgets in the window.
+// A handle at an edge resizes also the adjacent edge when the handle is dragged near a corner.
+func (c *Context) resizeHandles(cnt *container, id widgetID, bounds image.Rectangle) {
+	edge := c.style().spacing
+	corner := c.style().spacing * 2
+	// The bottom-right corner is large and inside the window as this is the main handle.
+	mainCorner := c.style().titleHeight
+
+	// The edge handles include the corners outside the window.
+	outer := bounds.Inset(-edge)
+	handles := []struct {
+		edges  resizeEdge
+		bounds image.Rectangle
+	}{
+		{resizeEdgeLeft, image.Rect(outer.Min.X, outer.Min.Y, bounds.Min.X, outer.Max.Y)},
+		{resizeEdgeRight, image.Rect(bounds.Max.X, outer.Min.Y, outer.Max.X, outer.Max.Y)},
+		{resizeEdgeTop, image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, bounds.Min.Y)},
+		{resizeEdgeBottom, image.Rect(outer.Min.X, bounds.Max.Y, outer.Max.X, outer.Max.Y)},
+		{resizeEdgeRight | resizeEdgeBottom, image.Rect(bounds.Max.X-mainCorner, bounds.Max.Y-mainCorner, bounds.Max.X, bounds.Max.Y)},
+	}
+	for _, h := range handles {
+		handleID := id.push(idPartFromInt(int(h.edges)))
+		_ = c.widgetWithBounds(handleID, optionResizeHandle, h.bounds, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if handleID != c.focus || !c.pointing.pressed() {
+				return nil
+			}
+			if c.pointing.justPressed() {
+				cnt.dragBounds = cnt.layout.Bounds
+				cnt.resizeEdges = h.edges
+				// Resize the adjacent edge too near a corner.
+				p := c.pointingPosition()
+				b := cnt.layout.Bounds
+				if h.edges&(resizeEdgeLeft|resizeEdgeRight) == 0 {
+					if p.X < b.Min.X+corner {
+						cnt.resizeEdges |= resizeEdgeLeft
+					} else if p.X >= b.Max.X-corner {
+						cnt.resizeEdges |= resizeEdgeRight
+					}
+				}
+				if h.edges&(resizeEdgeTop|resizeEdgeBottom) == 0 {
+					if p.Y < b.Min.Y+corner {
+						cnt.resizeEdges |= resizeEdgeTop
+					} else if p.Y >= b.Max.Y-corner {
+						cnt.resizeEdges |= resizeEdgeBottom
+					}
+				}
+			}
+			d := c.pointingDelta()
+			edges := cnt.resizeEdges
+			if edges&resizeEdgeLeft != 0 {
+				cnt.dragBounds.Min.X += d.X
+			}
+			if edges&resizeEdgeRight != 0 {
+				cnt.dragBounds.Max.X += d.X
+			}
+			if edges&resizeEdgeTop != 0 {
+				cnt.dragBounds.Min.Y += d.Y
+			}
+			if edges&resizeEdgeBottom != 0 {
+				cnt.dragBounds.Max.Y += d.Y
+			}
+			cnt.layout.Bounds = c.constrainWindowBounds(cnt, cnt.dragBounds, edges)
+			return nil
+		}, nil)
+	}
+}
+
+// constrainWindowBounds returns the bounds resized by dragging the edges, constrained by the size limits of the window.
+// The opposite edges of the dragged edges are fixed.
+func (c *Context) constrainWindowBounds(cnt *container, bounds image.Rectangle, edges resizeEdge) image.Rectangle {
+	minW, minH := cnt.minSize.X, cnt.minSize.Y
+	if minW <= 0 {
+		minW = defaultWindowMinWidth
+	}
+	if minH <= 0 {
+		minH = defaultWindowMinHeight
+	}
+	maxW, maxH := cnt.maxSize.X, cnt.maxSize.Y
+	if maxW <= 0 {
+		maxW = math.MaxInt
+	}
+	if maxH <= 0 {
+		maxH = math.MaxInt
+	}
+	maxW = max(maxW, minW)
+	maxH = max(maxH, minH)
+
+	w := clamp(bounds.Dx(), minW, maxW)
+	h := clamp(bounds.Dy(), minH, maxH)
+	if r := cnt.aspectRatio; r > 0 {
+		horizontal := edges&(resizeEdgeLeft|resizeEdgeRight) != 0
+		vertical := edges&(resizeEdgeTop|resizeEdgeBottom) != 0
+		if vertical && !horizontal {
+			w = clamp(int(float64(h)*r), minW, maxW)
+			h = int(float64(w) / r)
+		} else {
+			h = clamp(int(float64(w)/r), minH, maxH)
+			w = int(float64(h) * r)
+		}
+	}
+
+	// Fix the opposite edges of the dragged edges.
+	b := bounds
+	if edges&resizeEdgeLeft != 0 {
+		b.Min.X = b.Max.X - w
+	} else {
+		b.Max.X = b.Min.X + w
+	}
+	if edges&resizeEdgeTop != 0 {
+		b.Min.Y = b.Max.Y - h
+	} else {
+		b.Max.Y = b.Min.Y + h
+	}
+
+	// Keep the dragged edges in the screen.
+	screen := c.screenBounds()
+	if screen.Empty() {
+		return b
+	}
+	if edges&resizeEdgeLeft != 0 {
+		b.Min.X = max(b.Min.X, screen.Min.X)
+	} else {
+		b.Max.X = min(b.Max.X, max(screen.Max.X, b.Min.X+minW))
+	}
+	if edges&resizeEdgeTop != 0 {
+		b.Min.Y = max(b.Min.Y, screen.Min.Y)
+	} else {
+		b.Max.Y = min(b.Max.Y, max(screen.Max.Y, b.Min.Y+minH))
+	}
+	return b
+}
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// richRun is a run of text with the same style in a rich text.
+type richRun struct {
+	// start is the byte offset of the run in the plain text.
+	start int
+
+	// text is the text of the run. For an icon, text is the object replacement character.
+	text string
+
+	// color is the color of the text. If color is nil, the default text color is used.
+	color color.Color
+
+	bold bool
+
+	// icon is the inline icon. If icon is 0, the run is text.
+	icon icon
+}
+
+const objectReplacementChar = "\ufffc"
+
+var richTextColors = map[string]color.RGBA{
+	"red":    {0xff, 0x50, 0x50, 0xff},
+	"green":  {0x50, 0xe0, 0x50, 0xff},
+	"blue":   {0x60, 0x90, 0xff, 0xff},
+	"yellow": {0xff, 0xe0, 0x40, 0xff},
+	"orange": {0xff, 0xa0, 0x30, 0xff},
+	"gray":   {0xa0, 0xa0, 0xa0, 0xff},
+	"white":  {0xff, 0xff, 0xff, 0xff},
+}
+
+var richTextIcons = map[string]icon{
+	"check":     iconCheck,
+	"collapsed": iconCollapsed,
+	"expanded":  iconExpanded,
+	"down":      iconDown,
+	"up":        iconUp,
+	"close":     iconClose,
+}
+
+// parseRichText parses the markup and returns the runs and the plain text.
+//
+// The offsets of the runs are the byte offsets in the plain text.
+// A tag that is not recognized is treated as plain text.
+func parseRichText(markup string) ([]richRun, string) {
+	if !utf8.ValidString(markup) {
+		markup = sanitizeUTF8(markup)
+	}
+
+	var runs []richRun
+	var plain strings.Builder
+	var colors []color.Color
+	var boldDepth int
+
+	appendText := func(str string) {
+		if str == "" {
+			return
+		}
+		var clr color.Color
+		if len(colors) > 0 {
+			clr = colors[len(colors)-1]
+		}
+		bold := boldDepth > 0
+		// Merge the text into the last run if the style is the same.
+		if n := len(runs); n > 0 && runs[n-1].icon == 0 && runs[n-1].color == clr && runs[n-1].bold == bold {
+			runs[n-1].text += str
+		} else {
+			runs = append(runs, richRun{
+				start: plain.Len(),
+				text:  str,
+				color: clr,
+				bold:  bold,
+			})
+		}
+		plain.WriteString(str)
+	}
+
+	for len(markup) > 0 {
+		idx := strings.IndexByte(markup, '[')
+		if idx < 0 {
+			appendText(markup)
+			break
+		}
+		appendText(markup[:idx])
+		markup = markup[idx:]
+
+		// "[[" is an escaped "[".
+		if strings.HasPrefix(markup, "[[") {
+			appendText("[")
+			markup = markup[2:]
+			continue
+		}
+
+		end := strings.IndexByte(markup, ']')
+		if end < 0 {
+			appendText(markup)
+			break
+		}
+		tag := markup[1:end]
+		name, value, _ := strings.Cut(tag, "=")
+		switch {
+		case name == "b" && value == "":
+			boldDepth++
+		case name == "/b" && value == "" && boldDepth > 0:
+			boldDepth--
+		case name == "color":
+			clr, ok := parseRichTextColor(value)
+			if !ok {
+				appendText(markup[:end+1])
+				break
+			}
+			colors = append(colors, clr)
+		case name == "/color" && value == "" && len(colors) > 0:
+			colors = colors[:len(colors)-1]
+		case name == "icon":
+			icon, ok := richTextIcons[value]
+			if !ok {
+				appendText(markup[:end+1])
+				break
+			}
+			runs = append(runs, richRun{
+				start: plain.Len(),
+				text:  objectReplacementChar,
+				icon:  icon,
+			})
+			plain.WriteString(objectReplacementChar)
+		default:
+			appendText(markup[:end+1])
+		}
+		markup = markup[end+1:]
+	}
+
+	return runs, plain.String()
+}
+
+// parseRichTextColor parses a color name or a hexadecimal color in the form of #RGB, #RRGGBB or #RRGGBBAA.
+func parseRichTextColor(str string) (color.Color, bool) {
+	if clr, ok := richTextColors[str]; ok {
+		return clr, true
+	}
+	hex, ok := strings.CutPrefix(str, "#")
+	if !ok {
+		return nil, false
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return color.NRGBA{R: byte(v >> 24), G: byte(v >> 16), B: byte(v >> 8), A: byte(v)}, true
+}
+
+// richTextWidth returns the width of the rich text in the given byte range of the plain text.
+func (c *Context) richTextWidth(runs []richRun, start, end int) int {
+	var w int
+	for _, run := range runs {
+		s := max(start, run.start)
+		e := min(end, run.start+len(run.text))
+		if s >= e {
+			continue
+		}
+		if run.icon != 0 {
+			w += c.lineHeight()
+			continue
+		}
+		w += c.styledTextWidth(run.text[s-run.start:e-run.start], run.bold)
+	}
+	return w
+}
+
+// RichText creates a text label with a simple markup.
+//
+// The following tags are available:
+//
+//   - [b]...[/b]: Bold text.
+//   - [color=name]...[/color]: Colored text. name is one of red, green, blue, yellow, orange, gray and white,
+//     or a hexadecimal color in the form of #RGB, #RRGGBB or #RRGGBBAA.
+//   - [icon=name]: An inline icon. name is one of check, collapsed, expanded, down, up and close.
+//
+// "[[" is an escaped "[". A tag that is not recognized is shown as it is.
+//
+// The text is wrapped in the same way as Text.
+//
+// For example, "Loading... [color=red][b]ERROR[/b][/color]: file not found" shows ERROR in bold red.
+func (c *Context) RichText(markup string) {
+	runs, plain := parseRichText(markup)
+	c.GridCell(func(bounds image.Rectangle) {
+		for start, end := range c.lineRanges(plain, bounds.Dx()-c.style().padding, func(start, end int) int {
+			return c.richTextWidth(runs, start, end)
+		}) {
+			_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+				c.drawRichTextLine(runs, start, end, bounds)
+			})
+		}
+	})
+}
+
+// drawRichTextLine draws the rich text in the given byte range of the plain text.
+func (c *Context) drawRichTextLine(runs []richRun, start, end int, bounds image.Rectangle) {
+	c.pushClipRect(bounds)
+	defer c.popClipRect()
+
+	x := bounds.Min.X + c.style().padding
+	y := bounds.Min.Y + (bounds.Dy()-c.lineHeight())/2
+	for _, run := range runs {
+		s := max(start, run.start)
+		e := min(end, run.start+len(run.text))
+		if s >= e {
+			continue
+		}
+		clr := run.color
+		if clr == nil {
+			clr = c.style().colors[colorText]
+		}
+		if run.icon != 0 {
+			size := c.lineHeight()
+			c.drawIcon(run.icon, image.Rect(x, y, x+size, y+size), clr)
+			x += size
+			continue
+		}
+		str := run.text[s-run.start : e-run.start]
+		c.drawStyledText(str, image.Pt(x, y), clr, run.bold)
+		x += c.styledTextWidth(str, run.bold)
+	}
+}
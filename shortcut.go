@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type modifier int
+
+const (
+	modifierCtrl modifier = 1 << iota
+	modifierShift
+	modifierAlt
+	modifierMeta
+)
+
+// shortcut is a key chord such as Ctrl+Shift+P.
+type shortcut struct {
+	key       ebiten.Key
+	modifiers modifier
+}
+
+// parseShortcut parses a key chord such as "F1" or "Ctrl+Shift+P".
+//
+// The modifiers are Ctrl, Shift, Alt and Meta, and the key is a name accepted by ebiten.Key's UnmarshalText.
+func parseShortcut(str string) (shortcut, error) {
+	var s shortcut
+	names := strings.Split(str, "+")
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if i == len(names)-1 {
+			if err := s.key.UnmarshalText([]byte(name)); err != nil {
+				return shortcut{}, fmt.Errorf("debugui: invalid key in shortcut %q", str)
+			}
+			break
+		}
+		var m modifier
+		switch strings.ToLower(name) {
+		case "ctrl", "control":
+			m = modifierCtrl
+		case "shift":
+			m = modifierShift
+		case "alt", "option":
+			m = modifierAlt
+		case "meta", "cmd", "command", "super":
+			m = modifierMeta
+		default:
+			return shortcut{}, fmt.Errorf("debugui: invalid modifier in shortcut %q", str)
+		}
+		if s.modifiers&m != 0 {
+			return shortcut{}, fmt.Errorf("debugui: duplicated modifier in shortcut %q", str)
+		}
+		s.modifiers |= m
+	}
+	return s, nil
+}
+
+// String returns the key chord in the canonical form such as "Ctrl+Shift+P".
+func (s shortcut) String() string {
+	var b strings.Builder
+	for _, m := range []struct {
+		modifier modifier
+		name     string
+	}{
+		{modifierCtrl, "Ctrl"},
+		{modifierShift, "Shift"},
+		{modifierAlt, "Alt"},
+		{modifierMeta, "Meta"},
+	} {
+		if s.modifiers&m.modifier != 0 {
+			b.WriteString(m.name)
+			b.WriteString("+")
+		}
+	}
+	b.WriteString(strings.TrimPrefix(s.key.String(), "Digit"))
+	return b.String()
+}
+
+// shortcutPressed reports whether the key of the shortcut is just pressed with exactly the modifiers of the shortcut.
+func (c *Context) shortcutPressed(s shortcut) bool {
+	if !c.isKeyJustPressed(s.key) {
+		return false
+	}
+	var m modifier
+	if c.isKeyPressed(ebiten.KeyControl) {
+		m |= modifierCtrl
+	}
+	if c.isKeyPressed(ebiten.KeyShift) {
+		m |= modifierShift
+	}
+	if c.isKeyPressed(ebiten.KeyAlt) {
+		m |= modifierAlt
+	}
+	if c.isKeyPressed(ebiten.KeyMeta) {
+		m |= modifierMeta
+	}
+	return m == s.modifiers
+}
+
+// Shortcut returns an EventHandler to handle events when the key chord is pressed.
+//
+// chord is a key with optional modifiers joined by "+", such as "F1" or "Ctrl+Shift+P".
+// The modifiers are Ctrl, Shift, Alt and Meta. The key is a key name of Ebitengine, such as "A", "1", "F1" or "Escape".
+//
+// A shortcut is active only while Shortcut is called in the tick.
+// The same chord cannot be used more than once in a tick, and Update returns an error for such a conflict.
+// The chord set by [DebugUI.SetToggleShortcut] cannot be used either.
+// A shortcut doesn't work while a text field has focus, or while a modal window not including the shortcut is open.
+//
+// A returned EventHandler is never nil.
+func (c *Context) Shortcut(chord string) EventHandler {
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		_, pressed, err := c.shortcut(chord)
+		if err != nil {
+			return nil, err
+		}
+		if pressed {
+			return &eventHandler{}, nil
+		}
+		return nil, nil
+	})
+}
+
+// WindowShortcut toggles the window by the key chord.
+//
+// Pressing the key chord closes the window if the window is open, and opens the window otherwise.
+// See [Context.Shortcut] for the format of chord.
+func (c *Context) WindowShortcut(chord string, windowID WindowID) {
+	c.Shortcut(chord).On(func() {
+		if c.IsWindowOpen(windowID) {
+			c.CloseWindow(windowID)
+		} else {
+			c.OpenWindow(windowID)
+		}
+	})
+}
+
+// ButtonWithShortcut creates a button widget with the given label, and the key chord shown next to the label.
+//
+// ButtonWithShortcut returns an EventHandler to handle events when the button is clicked or the key chord is pressed.
+// A returned EventHandler is never nil.
+// See [Context.Shortcut] for the format of chord.
+//
+// A ButtonWithShortcut widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) ButtonWithShortcut(label string, chord string) WidgetEventHandler {
+	pc := caller()
+	id := c.idStack.push(idPartFromCaller(pc))
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		s, pressed, err := c.shortcut(chord)
+		if err != nil {
+			return nil, err
+		}
+		e, err := c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if pressed || (c.pointing.justPressed() && c.focus == id) {
+				return &eventHandler{}
+			}
+			return nil
+		}, func(bounds image.Rectangle) {
+			c.drawWidgetFrame(id, bounds, colorButton, 0)
+			str := s.String()
+			shortcutBounds := bounds
+			shortcutBounds.Min.X = max(bounds.Min.X, bounds.Max.X-c.textWidth(str)-c.style().padding*2)
+			y := bounds.Min.Y + (bounds.Dy()-c.lineHeight())/2
+			c.pushClipRect(bounds)
+			c.drawText(str, image.Pt(shortcutBounds.Min.X+c.style().padding, y), c.style().colors[colorTextDimmed])
+			c.popClipRect()
+			bounds.Max.X = shortcutBounds.Min.X
+			c.drawTruncatedWidgetText(label, bounds, colorText, 0)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+// shortcut registers the key chord for the current tick,
+// and reports whether the key chord is pressed and the shortcut is not suppressed.
+func (c *Context) shortcut(chord string) (shortcut, bool, error) {
+	s, ok := c.parsedShortcuts[chord]
+	if !ok {
+		var err error
+		s, err = parseShortcut(chord)
+		if err != nil {
+			return shortcut{}, false, err
+		}
+		if c.parsedShortcuts == nil {
+			c.parsedShortcuts = map[string]shortcut{}
+		}
+		c.parsedShortcuts[chord] = s
+	}
+
+	if _, ok := c.shortcuts[s]; ok {
+		return shortcut{}, false, fmt.Errorf("debugui: shortcut %s is used more than once", s)
+	}
+	if c.shortcuts == nil {
+		c.shortcuts = map[shortcut]struct{}{}
+	}
+	c.shortcuts[s] = struct{}{}
+
+	// Typing in a text field must not trigger shortcuts.
+	if c.textFieldFocused() {
+		return s, false, nil
+	}
+	if modal := c.topModal(); modal != nil {
+		if len(c.containerStack) == 0 || !c.currentRootContainer().belongsTo(modal) {
+			return s, false, nil
+		}
+	}
+	return s, c.shortcutPressed(s), nil
+}
+
+// textFieldFocused reports whether a text field has focus.
+func (c *Context) textFieldFocused() bool {
+	return c.focus != (widgetID{}) && c.focus == c.textFieldFocus
+}
@@ -22,10 +22,10 @@ import (
 // A Slider widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) Slider(value *int, low, high int, step int) EventHandler {
+func (c *Context) Slider(value *int, low, high int, step int) WidgetEventHandler {
 	pc := caller()
 	id := c.idStack.push(idPartFromCaller(pc))
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.slider(value, low, high, step, id, optionAlignCenter)
 	})
 }
@@ -41,10 +41,10 @@ func (c *Context) Slider(value *int, low, high int, step int) EventHandler {
 // A SliderF widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) SliderF(value *float64, low, high float64, step float64, digits int) EventHandler {
+func (c *Context) SliderF(value *float64, low, high float64, step float64, digits int) WidgetEventHandler {
 	pc := caller()
 	id := c.idStack.push(idPartFromCaller(pc))
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.sliderF(value, low, high, step, digits, id, optionAlignCenter)
 	})
 }
@@ -68,8 +68,8 @@ func (c *Context) slider(value *int, low, high, step int, id widgetID, opt optio
 	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
 		if c.focus == id && c.pointing.pressed() {
-			if w := bounds.Dx() - defaultStyle.thumbSize; w > 0 {
-				v = low + (c.pointingPosition().X-bounds.Min.X-defaultStyle.thumbSize/2)*(high-low+step)/w
+			if w := bounds.Dx() - c.style().thumbSize; w > 0 {
+				v = low + (c.pointingPosition().X-bounds.Min.X-c.style().thumbSize/2)*(high-low+step)/w
 			}
 			if step != 0 {
 				v = v / step * step
@@ -114,8 +114,8 @@ func (c *Context) sliderF(value *float64, low, high, step float64, digits int, i
 	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
 		if c.focus == id && c.pointing.pressed() {
-			if w := float64(bounds.Dx() - defaultStyle.thumbSize); w > 0 {
-				v = low + float64(c.pointingPosition().X-bounds.Min.X-defaultStyle.thumbSize/2)*(high-low+step)/w
+			if w := float64(bounds.Dx() - c.style().thumbSize); w > 0 {
+				v = low + float64(c.pointingPosition().X-bounds.Min.X-c.style().thumbSize/2)*(high-low+step)/w
 			}
 			if step != 0 {
 				v = math.Round(v/step) * step
@@ -142,7 +142,7 @@ func (c *Context) sliderF(value *float64, low, high, step float64, digits int, i
 }
 
 func (c *Context) numberTextField(value *int, id widgetID) error {
-	if c.pointing.justPressed() && ebiten.IsKeyPressed(ebiten.KeyShift) && c.hover == id {
+	if c.pointing.justPressed() && c.isKeyPressed(ebiten.KeyShift) && c.hover == id {
 		c.numberEdit = id
 		c.numberEditBuf = fmt.Sprintf("%d", *value)
 	}
@@ -166,7 +166,7 @@ func (c *Context) numberTextField(value *int, id widgetID) error {
 }
 
 func (c *Context) numberTextFieldF(value *float64, id widgetID) error {
-	if c.pointing.justPressed() && ebiten.IsKeyPressed(ebiten.KeyShift) && c.hover == id {
+	if c.pointing.justPressed() && c.isKeyPressed(ebiten.KeyShift) && c.hover == id {
 		c.numberEdit = id
 		c.numberEditBuf = fmt.Sprintf(realFmt, *value)
 	}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlogHandler returns a slog.Handler that adds records to the buffer in the given channel.
+//
+// Records below level are discarded. If level is nil, slog.LevelInfo is used.
+// The attributes of a record are appended to the message in the form of key=value.
+//
+// For example, slog.New(buffer.SlogHandler("game", nil)) creates a logger writing to the buffer.
+func (b *LogBuffer) SlogHandler(channel string, level slog.Leveler) slog.Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &slogHandler{
+		buffer:  b,
+		channel: channel,
+		level:   level,
+	}
+}
+
+type slogHandler struct {
+	buffer  *LogBuffer
+	channel string
+	level   slog.Leveler
+
+	// attrs is the formatted attributes added by WithAttrs.
+	attrs string
+
+	// prefix is the prefix of the keys added by WithGroup.
+	prefix string
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var b strings.Builder
+	b.WriteString(record.Message)
+	b.WriteString(h.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		appendSlogAttr(&b, h.prefix, attr)
+		return true
+	})
+
+	t := record.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	h.buffer.add(LogEntry{
+		Time:    t,
+		Level:   logLevelFromSlog(record.Level),
+		Channel: h.channel,
+		Message: b.String(),
+	})
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, attr := range attrs {
+		appendSlogAttr(&b, h.prefix, attr)
+	}
+	h2 := *h
+	h2.attrs = b.String()
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func appendSlogAttr(b *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			appendSlogAttr(b, prefix, a)
+		}
+		return
+	}
+	b.WriteString(" ")
+	b.WriteString(prefix)
+	b.WriteString(attr.Key)
+	b.WriteString("=")
+	str := attr.Value.String()
+	if str == "" || strings.ContainsFunc(str, func(r rune) bool {
+		return r == ' ' || r == '=' || r == '"' || !strconv.IsPrint(r)
+	}) {
+		str = strconv.Quote(str)
+	}
+	b.WriteString(str)
+}
+
+func logLevelFromSlog(level slog.Level) LogLevel {
+	switch {
+	case level >= slog.LevelError:
+		return LogLevelError
+	case level >= slog.LevelWarn:
+		return LogLevelWarn
+	case level >= slog.LevelInfo:
+		return LogLevelInfo
+	default:
+		return LogLevelDebug
+	}
+}
@@ -21,6 +21,7 @@ type style struct {
 
 const (
 	colorText = iota
+	colorTextDimmed
 	colorBorder
 	colorWindowBG
 	colorTitleBG
@@ -35,6 +36,8 @@ const (
 	colorBaseFocus
 	colorScrollBase
 	colorScrollThumb
+	colorModalBG
+	colorDockPreview
 	colorCount
 )
 
@@ -43,12 +46,13 @@ var defaultStyle style = style{
 	defaultHeight: 18,
 	padding:       5,
 	spacing:       4,
-	indent:        lineHeight(),
+	indent:        faceLineHeight(defaultFontFace),
 	titleHeight:   24,
 	scrollbarSize: 12,
 	thumbSize:     8,
 	colors: [...]color.RGBA{
 		colorText:               {230, 230, 230, 255},
+		colorTextDimmed:         {160, 160, 160, 255},
 		colorBorder:             {60, 60, 60, 255},
 		colorWindowBG:           {45, 45, 45, 230},
 		colorTitleBG:            {30, 30, 30, 255},
@@ -63,5 +67,7 @@ var defaultStyle style = style{
 		colorBaseFocus:          {40, 40, 40, 255},
 		colorScrollBase:         {43, 43, 43, 255},
 		colorScrollThumb:        {30, 30, 30, 255},
+		colorModalBG:            {0, 0, 0, 128},
+		colorDockPreview:        {90, 120, 200, 96},
 	},
 }
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TableColumn represents a column of a table.
+type TableColumn struct {
+	// Name is the name of the column shown in the header.
+	Name string
+
+	// Width is the initial width of the column in pixels.
+	// If Width is 0 or negative, the default width is used.
+	Width int
+}
+
+// TableSort represents the sort state of a table.
+//
+// The zero value represents the first column sorted in ascending order.
+type TableSort struct {
+	// Column is the index of the sorted column.
+	Column int
+
+	// Descending reports whether the column is sorted in descending order.
+	Descending bool
+}
+
+// TableOptions represents options for a table.
+type TableOptions struct {
+	// Sort is the sort state of the table.
+	//
+	// If Sort is not nil, clicking a column header updates Sort.
+	// Table doesn't sort rows by itself. The caller is responsible for sorting the rows by Sort.
+	Sort *TableSort
+
+	// Selection is the indices of the selected rows in ascending order.
+	//
+	// The indices are the row indices passed to the cell function, not the identities of the rows.
+	// Table doesn't update Selection when the sort state changes.
+	// If the caller sorts the rows, the caller is responsible for remapping or clearing Selection.
+	//
+	// If Selection is nil, rows are not selectable.
+	Selection *[]int
+
+	// MultiSelect specifies whether multiple rows can be selected.
+	//
+	// If MultiSelect is true, a row is added to or removed from the selection by clicking with the Control key,
+	// and a range of rows is selected by clicking with the Shift key.
+	MultiSelect bool
+}
+
+const tableMinColumnWidth = 16
+
+type tableState struct {
+	// columnWidths is the widths of the columns.
+	columnWidths []int
+
+	// selectionAnchor is the row index where a range selection starts.
+	selectionAnchor int
+}
+
+// Table creates a table widget with the given columns and the number of rows.
+//
+// cell is called for each cell of the visible rows to lay out the content of the cell.
+// Each cell is a grid cell like [GridCell]. Only the visible rows are laid out, so Table can handle a large number of rows.
+//
+// The table occupies one grid cell. Use [SetGridLayout] to specify the size of the table.
+// The header row is fixed at the top, and the body is scrolled.
+// The column widths can be changed by dragging the right edges of the headers,
+// and the widths are kept while the table is alive.
+// The rows have alternating background colors.
+//
+// options can be nil.
+//
+// Table returns an EventHandler to handle events when the selection or the sort state changes.
+// A returned EventHandler is never nil.
+//
+// A Table widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Table(columns []TableColumn, rowCount int, options *TableOptions, cell func(row, column int)) WidgetEventHandler {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		var e EventHandler
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			e, err = c.table(columns, rowCount, options, cell, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+func (c *Context) table(columns []TableColumn, rowCount int, options *TableOptions, cell func(row, column int), id widgetID) (EventHandler, error) {
+	if options == nil {
+		options = &TableOptions{}
+	}
+
+	cnt := c.container(id, 0)
+	state := widgetState[tableState](cnt, id)
+	bounds, err := c.layoutNext()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(state.columnWidths) != len(columns) {
+		state.columnWidths = make([]int, len(columns))
+		for i, column := range columns {
+			w := column.Width
+			if w <= 0 {
+				w = c.style().defaultWidth + c.style().padding*2
+			}
+			state.columnWidths[i] = w
+		}
+	}
+	widths := state.columnWidths
+
+	var e EventHandler
+	rowHeight := c.style().defaultHeight
+	header := bounds
+	header.Max.Y = header.Min.Y + rowHeight
+	body := bounds
+	body.Min.Y = header.Max.Y + 1
+
+	// Draw the header. The header follows the horizontal scroll of the body.
+	c.pushClipRect(header)
+	headerID := id.push(idPartFromString("header"))
+	resizeID := id.push(idPartFromString("resize"))
+	x := header.Min.X + c.style().padding - cnt.layout.ScrollOffset.X
+	for i, column := range columns {
+		r := image.Rect(x, header.Min.Y, x+widths[i], header.Max.Y)
+		columnID := headerID.push(idPartFromInt(i))
+		_ = c.widgetWithBounds(columnID, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if c.pointing.justPressed() && c.focus == columnID && options.Sort != nil {
+				if options.Sort.Column == i {
+					options.Sort.Descending = !options.Sort.Descending
+				} else {
+					options.Sort.Column = i
+					options.Sort.Descending = false
+				}
+				e = &eventHandler{}
+			}
+			return nil
+		}, func(bounds image.Rectangle) {
+			c.drawWidgetFrame(columnID, bounds, colorButton, 0)
+			if options.Sort != nil && options.Sort.Column == i {
+				iconBounds := image.Rect(bounds.Max.X-bounds.Dy(), bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+				icon := iconUp
+				if options.Sort.Descending {
+					icon = iconDown
+				}
+				c.drawIcon(icon, iconBounds, c.style().colors[colorText])
+				bounds.Max.X -= bounds.Dy()
+			}
+			c.drawWidgetText(column.Name, bounds, colorText, 0)
+		})
+
+		// Handle the resize handle at the right edge of the header.
+		handle := image.Rect(r.Max.X-2, r.Min.Y, r.Max.X+c.style().spacing, r.Max.Y)
+		columnResizeID := resizeID.push(idPartFromInt(i))
+		_ = c.widgetWithBounds(columnResizeID, 0, handle, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if c.focus == columnResizeID && c.pointing.pressed() {
+				widths[i] = max(tableMinColumnWidth, widths[i]+c.pointingDelta().X)
+			}
+			return nil
+		}, nil)
+
+		x += widths[i] + c.style().spacing
+	}
+	c.popClipRect()
+
+	// Draw the body.
+	rowID := id.push(idPartFromString("row"))
+	if err := c.doPanelWithBounds(cnt, body, 0, func(layout ContainerLayout) {
+		c.SetGridLayout(widths, nil)
+		if err := c.virtualRows(rowCount, rowHeight, func(row int) {
+			l, err := c.layout()
+			if err != nil {
+				c.err = err
+				return
+			}
+
+			// The row background covers the spacing between rows.
+			rowWidth := (len(widths) - 1) * c.style().spacing
+			for _, w := range widths {
+				rowWidth += w
+			}
+			pos := l.body.Min.Add(l.position)
+			rowBounds := image.Rect(pos.X, pos.Y-c.style().spacing/2, pos.X+rowWidth, pos.Y+rowHeight+c.style().spacing-c.style().spacing/2)
+			currentRowID := rowID.push(idPartFromInt(row))
+			var rowPressed bool
+			_ = c.widgetWithBounds(currentRowID, 0, rowBounds, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+				rowPressed = c.pointing.justPressed() && c.focus == currentRowID
+				return nil
+			}, func(bounds image.Rectangle) {
+				switch {
+				case options.Selection != nil && slices.Contains(*options.Selection, row):
+					c.drawRect(bounds, c.style().colors[colorButtonFocus])
+				case c.hover == currentRowID:
+					c.drawRect(bounds, c.style().colors[colorButtonHover])
+				case row%2 == 1:
+					c.drawRect(bounds, c.style().colors[colorBase])
+				}
+			})
+
+			c.idStack = c.idStack.push(idPartFromInt(row))
+			defer func() {
+				c.idStack = c.idStack.pop()
+			}()
+			for column := range columns {
+				c.idStack = c.idStack.push(idPartFromInt(column))
+				c.GridCell(func(bounds image.Rectangle) {
+					cell(row, column)
+				})
+				c.idStack = c.idStack.pop()
+			}
+
+			// The row is selected after the cells are laid out.
+			// If a widget in a cell like a button takes the focus, the row is not selected.
+			if rowPressed && c.focus == currentRowID && options.Selection != nil {
+				if c.selectRow(options.Selection, row, options.MultiSelect, &state.selectionAnchor) {
+					e = &eventHandler{}
+				}
+			}
+		}); err != nil && c.err == nil {
+			c.err = err
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// selectRow updates the selection by clicking the row.
+// anchor is the row where a range selection starts, which is updated by selectRow.
+//
+// selectRow returns true if the selection is changed.
+func (c *Context) selectRow(selection *[]int, row int, multiSelect bool, anchor *int) bool {
+	orig := slices.Clone(*selection)
+
+	ctrl := c.isKeyPressed(ebiten.KeyControl) || c.isKeyPressed(ebiten.KeyMeta)
+	shift := c.isKeyPressed(ebiten.KeyShift)
+	switch {
+	case multiSelect && shift:
+		from, to := min(*anchor, row), max(*anchor, row)
+		*selection = (*selection)[:0]
+		for i := from; i <= to; i++ {
+			*selection = append(*selection, i)
+		}
+	case multiSelect && ctrl:
+		if idx, found := slices.BinarySearch(*selection, row); found {
+			*selection = slices.Delete(*selection, idx, idx+1)
+		} else {
+			*selection = slices.Insert(*selection, idx, row)
+		}
+		*anchor = row
+	default:
+		*selection = append((*selection)[:0], row)
+		*anchor = row
+	}
+
+	return !slices.Equal(orig, *selection)
+}
@@ -8,6 +8,7 @@ import (
 	"iter"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 func removeSpaceAtLineTail(str string) string {
@@ -24,57 +25,113 @@ func sanitizeUTF8(s string) string {
 
 func (c *Context) lines(text string, width int) iter.Seq[string] {
 	return func(yield func(string) bool) {
+		if !utf8.ValidString(text) {
+			text = sanitizeUTF8(text)
+		}
+		for start, end := range c.lineRanges(text, width, func(start, end int) int {
+			return c.textWidth(text[start:end])
+		}) {
+			if !yield(text[start:end]) {
+				return
+			}
+		}
+	}
+}
+
+// lineRanges returns the byte ranges of the lines of the text wrapped in the given width.
+// measure returns the width of the text in the given byte range.
+//
+// text must be a valid UTF-8 string.
+func (c *Context) lineRanges(text string, width int, measure func(start, end int) int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
 		seg := c.pushSegmenter()
 		defer c.popSegmenter()
 
 		if err := seg.InitWithString(text); err != nil {
-			text = sanitizeUTF8(text)
-			if err := seg.InitWithString(text); err != nil {
-				panic("debugui: segmenter.InitWithString failed even after sanitizing: " + err.Error())
-			}
+			panic("debugui: segmenter.InitWithString failed: " + err.Error())
+		}
+
+		// trimEnd returns the end of the range without the spaces at the line tail.
+		trimEnd := func(start, end int) int {
+			return start + len(removeSpaceAtLineTail(text[start:end]))
 		}
 
-		var line string
+		start, end := -1, -1
 		it := seg.LineIterator()
 		for it.Next() {
 			l := it.Line()
-			segment := text[l.OffsetInBytes : l.OffsetInBytes+l.LengthInBytes]
+			segStart, segEnd := l.OffsetInBytes, l.OffsetInBytes+l.LengthInBytes
 
-			if line == "" {
-				line = segment
+			if start < 0 {
+				start, end = segStart, segEnd
 			} else {
-				if trimmed := removeSpaceAtLineTail(line + segment); textWidth(trimmed) > width {
-					if !yield(removeSpaceAtLineTail(line)) {
+				if measure(start, trimEnd(start, segEnd)) > width {
+					if !yield(start, trimEnd(start, end)) {
 						return
 					}
-					line = segment
-				} else {
-					line += segment
+					start = segStart
 				}
+				end = segEnd
 			}
 
 			if l.IsMandatoryBreak {
-				if !yield(removeSpaceAtLineTail(line)) {
+				if !yield(start, trimEnd(start, end)) {
 					return
 				}
-				line = ""
+				start, end = -1, -1
 			}
 		}
 
-		if len(line) > 0 {
-			if !yield(removeSpaceAtLineTail(line)) {
+		if start >= 0 && end > start {
+			if !yield(start, trimEnd(start, end)) {
 				return
 			}
 		}
 	}
 }
 
+// TextAlign represents a horizontal alignment of text.
+type TextAlign int
+
+const (
+	// TextAlignLeft aligns text to the left.
+	TextAlignLeft TextAlign = iota
+
+	// TextAlignCenter aligns text to the center.
+	TextAlignCenter
+
+	// TextAlignRight aligns text to the right.
+	TextAlignRight
+)
+
+func (a TextAlign) option() option {
+	switch a {
+	case TextAlignCenter:
+		return optionAlignCenter
+	case TextAlignRight:
+		return optionAlignRight
+	default:
+		return 0
+	}
+}
+
 // Text creates a text label.
 func (c *Context) Text(text string) {
+	c.text(text, 0)
+}
+
+// TextWithAlign creates a text label with the given alignment.
+//
+// Each wrapped line is aligned individually.
+func (c *Context) TextWithAlign(text string, align TextAlign) {
+	c.text(text, align.option())
+}
+
+func (c *Context) text(text string, opt option) {
 	c.GridCell(func(bounds image.Rectangle) {
 		for line := range c.lines(text, bounds.Dx()-c.style().padding) {
 			_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
-				c.drawWidgetText(line, bounds, colorText, 0)
+				c.drawWidgetText(line, bounds, colorText, opt)
 			})
 		}
 	})
@@ -11,7 +11,6 @@ import (
 	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 const (
@@ -27,10 +26,10 @@ const (
 // A TextField widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) TextField(buf *string) EventHandler {
+func (c *Context) TextField(buf *string) WidgetEventHandler {
 	pc := caller()
 	id := c.idStack.push(idPartFromCaller(pc))
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.textField(buf, id, 0)
 	})
 }
@@ -41,11 +40,14 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 
 		f := c.currentContainer().textInputTextField(id, true)
 		if c.focus == id {
+			c.textFieldFocus = id
+
 			// handle text input
 			f.Focus()
-			x := bounds.Min.X + c.style().padding + textWidth(*buf)
-			y := bounds.Min.Y + lineHeight()
-			handled, err := f.HandleInput(x, y)
+			_, caret := f.Selection()
+			x := bounds.Min.X + c.style().padding + c.caretPosition(*buf, caret)
+			y := bounds.Min.Y + c.lineHeight()
+			handled, err := c.input().handleTextInput(f, x, y)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
 				return nil
@@ -55,12 +57,22 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 			}
 
 			if !handled {
-				if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(*buf) > 0 {
-					_, size := utf8.DecodeLastRuneInString(*buf)
-					*buf = (*buf)[:len(*buf)-size]
-					f.SetTextAndSelection(*buf, len(*buf), len(*buf))
+				// The caret moves in the visual order, which is different from the logical order in right-to-left text.
+				_, caret := f.Selection()
+				if c.keyRepeated(ebiten.KeyLeft) {
+					caret = c.moveCaret(*buf, caret, -1)
+					f.SetSelection(caret, caret)
+				}
+				if c.keyRepeated(ebiten.KeyRight) {
+					caret = c.moveCaret(*buf, caret, 1)
+					f.SetSelection(caret, caret)
 				}
-				if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+				if c.isKeyJustPressed(ebiten.KeyBackspace) && caret > 0 {
+					_, size := utf8.DecodeLastRuneInString((*buf)[:caret])
+					*buf = (*buf)[:caret-size] + (*buf)[caret:]
+					f.SetTextAndSelection(*buf, caret-size, caret-size)
+				}
+				if c.isKeyJustPressed(ebiten.KeyEnter) {
 					e = &eventHandler{}
 				}
 			}
@@ -79,20 +91,27 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 			f := c.currentContainer().textInputTextField(id, true)
 
 			color := c.style().colors[colorText]
-			textw := textWidth(*buf)
-			texth := lineHeight()
-			ofx := bounds.Dx() - c.style().padding - textw - 1
+			str := f.TextForRendering()
+			start, _ := f.Selection()
+			textw := c.textWidth(str)
+			texth := c.lineHeight()
+			caretx := c.caretPosition(str, start+f.UncommittedTextLengthInBytes())
+			ofx := bounds.Dx() - c.style().padding - caretx - 1
 			textx := bounds.Min.X + min(ofx, c.style().padding)
 			switch {
 			case opt&optionAlignCenter != 0:
 				textx = bounds.Min.X + (bounds.Dx()-textw)/2
 			case opt&optionAlignRight != 0:
 				textx = bounds.Min.X + bounds.Dx() - textw - c.style().padding
+			case isRTLParagraph(*buf):
+				// A right-to-left paragraph is aligned to the right, and scrolled to keep the caret visible.
+				textx = bounds.Min.X + bounds.Dx() - textw - c.style().padding
+				textx = max(textx, bounds.Min.X+c.style().padding-caretx)
 			}
 			texty := bounds.Min.Y + (bounds.Dy()-texth)/2
 			c.pushClipRect(bounds)
-			c.drawText(f.TextForRendering(), image.Pt(textx, texty), color)
-			c.drawRect(image.Rect(textx+textw, texty, textx+textw+1, texty+texth), color)
+			c.drawText(str, image.Pt(textx, texty), color)
+			c.drawRect(image.Rect(textx+caretx, texty, textx+caretx+1, texty+texth), color)
 			c.popClipRect()
 		} else {
 			c.drawWidgetText(*buf, bounds, colorText, opt)
@@ -106,7 +125,7 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 func (c *Context) SetTextFieldValue(value string) {
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		if f := c.currentContainer().textInputTextField(c.currentID, false); f != nil {
-			f.SetTextAndSelection(value, 0, 0)
+			f.SetTextAndSelection(value, len(value), len(value))
 		}
 		return nil, nil
 	})
@@ -126,10 +145,10 @@ func (c *Context) textField(buf *string, id widgetID, opt option) (EventHandler,
 // A NumberField widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) NumberField(value *int, step int) EventHandler {
+func (c *Context) NumberField(value *int, step int) WidgetEventHandler {
 	pc := caller()
 	idPart := idPartFromCaller(pc)
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.numberField(value, step, idPart, optionAlignRight)
 	})
 }
@@ -145,10 +164,10 @@ func (c *Context) NumberField(value *int, step int) EventHandler {
 // A NumberFieldF widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) NumberFieldF(value *float64, step float64, digits int) EventHandler {
+func (c *Context) NumberFieldF(value *float64, step float64, digits int) WidgetEventHandler {
 	pc := caller()
 	idPart := idPartFromCaller(pc)
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.numberFieldF(value, step, digits, idPart, optionAlignRight)
 	})
 }
@@ -160,7 +179,7 @@ func (c *Context) numberField(value *int, step int, idPart string, opt option) (
 	var err error
 	c.idScopeFromIDPart(idPart, func(id widgetID) {
 		c.GridCell(func(bounds image.Rectangle) {
-			c.SetGridLayout([]int{-1, lineHeight()}, nil)
+			c.SetGridLayout([]int{-1, c.lineHeight()}, nil)
 
 			buf := fmt.Sprintf("%d", *value)
 			e1, err1 := c.textFieldRaw(&buf, id, opt)
@@ -183,17 +202,17 @@ func (c *Context) numberField(value *int, step int, idPart string, opt option) (
 			}
 			if c.focus == id {
 				var updated bool
-				if keyRepeated(ebiten.KeyUp) || keyRepeated(ebiten.KeyDown) {
+				if c.keyRepeated(ebiten.KeyUp) || c.keyRepeated(ebiten.KeyDown) {
 					v, err := strconv.ParseInt(buf, 10, 64)
 					if err != nil {
 						v = 0
 					}
 					*value = int(v)
 					updated = true
-					if keyRepeated(ebiten.KeyUp) {
+					if c.keyRepeated(ebiten.KeyUp) {
 						*value += step
 					}
-					if keyRepeated(ebiten.KeyDown) {
+					if c.keyRepeated(ebiten.KeyDown) {
 						*value -= step
 						updated = true
 					}
@@ -236,7 +255,7 @@ func (c *Context) numberFieldF(value *float64, step float64, digits int, idPart
 	var err error
 	c.idScopeFromIDPart(idPart, func(id widgetID) {
 		c.GridCell(func(bounds image.Rectangle) {
-			c.SetGridLayout([]int{-1, lineHeight()}, nil)
+			c.SetGridLayout([]int{-1, c.lineHeight()}, nil)
 
 			buf := formatNumber(*value, digits)
 			e1, err1 := c.textFieldRaw(&buf, id, opt)
@@ -259,17 +278,17 @@ func (c *Context) numberFieldF(value *float64, step float64, digits int, idPart
 			}
 			if c.focus == id {
 				var updated bool
-				if keyRepeated(ebiten.KeyUp) || keyRepeated(ebiten.KeyDown) {
+				if c.keyRepeated(ebiten.KeyUp) || c.keyRepeated(ebiten.KeyDown) {
 					v, err := strconv.ParseFloat(buf, 64)
 					if err != nil {
 						v = 0
 					}
 					*value = float64(v)
 					updated = true
-					if keyRepeated(ebiten.KeyUp) {
+					if c.keyRepeated(ebiten.KeyUp) {
 						*value += step
 					}
-					if keyRepeated(ebiten.KeyDown) {
+					if c.keyRepeated(ebiten.KeyDown) {
 						*value -= step
 						updated = true
 					}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"errors"
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const defaultTooltipDelay = 500 * time.Millisecond
+
+type tooltip struct {
+	// bounds is the bounds of the widget on which the pointing device rests.
+	bounds image.Rectangle
+
+	// count is the number of ticks while the pointing device rests on the widget.
+	count int
+
+	// touched reports whether a tooltip was requested for bounds in the current tick.
+	touched bool
+
+	// text is the tooltip text to show in the current tick.
+	text string
+}
+
+// Tooltip sets a tooltip text for the last widget.
+//
+// The tooltip is shown near the pointing position
+// after the pointing device rests on the widget for the delay specified by SetTooltipDelay.
+//
+// Tooltip is useful for a widget without a WidgetEventHandler, such as Text.
+// For a widget returning a WidgetEventHandler, [WidgetEventHandler.Tooltip] can be chained instead.
+func (c *Context) Tooltip(text string) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if len(c.containerStack) == 0 {
+			return nil, errors.New("debugui: Tooltip must be called in a window")
+		}
+		c.setTooltip(c.currentBounds, text)
+		return nil, nil
+	})
+}
+
+// SetTooltipDelay sets the delay before a tooltip is shown.
+//
+// The default delay is 500 milliseconds.
+func (c *Context) SetTooltipDelay(delay time.Duration) {
+	c.tooltipDelay = delay
+	c.hasTooltipDelay = true
+}
+
+func (c *Context) tooltipDelayInTicks() int {
+	delay := defaultTooltipDelay
+	if c.hasTooltipDelay {
+		delay = c.tooltipDelay
+	}
+	return int(delay.Seconds() * float64(ebiten.TPS()))
+}
+
+func (c *Context) setTooltip(bounds image.Rectangle, text string) {
+	if text == "" || bounds.Empty() {
+		return
+	}
+	if !c.pointingOver(bounds) {
+		return
+	}
+	if c.tooltip.bounds != bounds {
+		c.tooltip.bounds = bounds
+		c.tooltip.count = 0
+	}
+	c.tooltip.touched = true
+	if c.tooltip.count >= c.tooltipDelayInTicks() {
+		c.tooltip.text = text
+	}
+}
+
+func (c *Context) updateTooltip() {
+	if c.tooltip.touched && !c.pointing.pressed() {
+		c.tooltip.count++
+	} else {
+		c.tooltip.count = 0
+	}
+	c.tooltip.touched = false
+	c.tooltip.text = ""
+}
+
+func (c *Context) tooltipWindow() error {
+	if c.tooltip.text == "" {
+		return nil
+	}
+	text := c.tooltip.text
+	opt := optionTooltip | optionAutoSize | optionNoResize | optionNoScroll | optionNoTitle
+	return c.window("", image.Rectangle{}, opt, idPartFromString("tooltip"), func(layout ContainerLayout) {
+		maxWidth := c.style().defaultWidth * 4
+		var w int
+		for line := range c.lines(text, maxWidth) {
+			w = max(w, c.textWidth(line))
+		}
+		c.SetGridLayout([]int{w + c.style().padding*2}, []int{c.lineHeight()})
+		for line := range c.lines(text, maxWidth) {
+			_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+				return c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+					c.drawWidgetText(line, bounds, colorText, 0)
+				})
+			})
+		}
+	})
+}
+
+// placeTooltip places the tooltip window near the pointing position within the screen.
+func (c *Context) placeTooltip(cnt *container) {
+	b := cnt.layout.Bounds
+	p := c.pointingPosition()
+	pos := p.Add(image.Pt(c.style().padding, c.style().titleHeight))
+	if screen := c.screenBounds(); !screen.Empty() {
+		if pos.X+b.Dx() > screen.Max.X {
+			pos.X = screen.Max.X - b.Dx()
+		}
+		if pos.Y+b.Dy() > screen.Max.Y {
+			pos.Y = p.Y - b.Dy() - c.style().padding
+		}
+		pos.X = max(pos.X, screen.Min.X)
+		pos.Y = max(pos.Y, screen.Min.Y)
+	}
+	cnt.layout.Bounds = b.Add(pos.Sub(b.Min))
+	c.bringToFront(cnt)
+}
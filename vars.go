@@ -0,0 +1,599 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Var is a tunable variable registered in the global registry.
+//
+// A Var is created by a Register*Var function, typically at init time, and is shown by [Context.Vars].
+// The value of a Var must be accessed on the same goroutine as the debug UI.
+type Var[T comparable] struct {
+	path         string
+	description  string
+	value        T
+	defaultValue T
+
+	// low and high are the range of an int or float64 value. If low >= high, the range is unlimited.
+	low  float64
+	high float64
+
+	// enumNames is the names of the enum values. enumNames is non-nil only for enum vars.
+	enumNames []string
+}
+
+// Path returns the path of the var, such as "render/shadows/bias".
+func (v *Var[T]) Path() string {
+	return v.path
+}
+
+// Description returns the description of the var.
+func (v *Var[T]) Description() string {
+	return v.description
+}
+
+// Value returns the current value of the var.
+func (v *Var[T]) Value() T {
+	return v.value
+}
+
+// SetValue sets the value of the var.
+//
+// An int or float64 value is clamped to the range of the var.
+func (v *Var[T]) SetValue(value T) {
+	v.value = value
+	v.clamp()
+}
+
+// Default returns the default value of the var.
+func (v *Var[T]) Default() T {
+	return v.defaultValue
+}
+
+// Reset sets the value of the var to the default value.
+func (v *Var[T]) Reset() {
+	v.value = v.defaultValue
+}
+
+func (v *Var[T]) hasRange() bool {
+	return v.low < v.high
+}
+
+func (v *Var[T]) clamp() {
+	switch p := any(&v.value).(type) {
+	case *int:
+		if v.enumNames != nil {
+			*p = clamp(*p, 0, len(v.enumNames)-1)
+		} else if v.hasRange() {
+			*p = clamp(*p, int(v.low), int(v.high))
+		}
+	case *float64:
+		if v.hasRange() {
+			*p = clamp(*p, v.low, v.high)
+		}
+	}
+}
+
+func (v *Var[T]) varPath() string {
+	return v.path
+}
+
+func (v *Var[T]) varDescription() string {
+	return v.description
+}
+
+func (v *Var[T]) overridden() bool {
+	return v.value != v.defaultValue
+}
+
+func (v *Var[T]) matches(search string) bool {
+	return strings.Contains(strings.ToLower(v.path), search) || strings.Contains(strings.ToLower(v.description), search)
+}
+
+func (v *Var[T]) marshalValue() ([]byte, error) {
+	switch p := any(&v.value).(type) {
+	case *int:
+		if v.enumNames != nil {
+			return json.Marshal(v.enumNames[*p])
+		}
+	case *color.NRGBA:
+		return json.Marshal(fmt.Sprintf("#%02x%02x%02x%02x", p.R, p.G, p.B, p.A))
+	}
+	return json.Marshal(v.value)
+}
+
+func (v *Var[T]) unmarshalValue(data []byte) error {
+	switch p := any(&v.value).(type) {
+	case *int:
+		if v.enumNames != nil {
+			var name string
+			if err := json.Unmarshal(data, &name); err != nil {
+				return err
+			}
+			idx := slices.Index(v.enumNames, name)
+			if idx < 0 {
+				return fmt.Errorf("debugui: unknown value %q for var %s", name, v.path)
+			}
+			*p = idx
+			return nil
+		}
+	case *color.NRGBA:
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		clr, ok := parseRichTextColor(str)
+		if !ok || !strings.HasPrefix(str, "#") {
+			return fmt.Errorf("debugui: invalid color %q for var %s", str, v.path)
+		}
+		*p = clr.(color.NRGBA)
+		return nil
+	}
+	value := v.defaultValue
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("debugui: invalid value for var %s: %w", v.path, err)
+	}
+	v.SetValue(value)
+	return nil
+}
+
+// varEntry is the type-independent interface of Var.
+type varEntry interface {
+	varPath() string
+	overridden() bool
+	matches(search string) bool
+	marshalValue() ([]byte, error)
+	unmarshalValue(data []byte) error
+	varDescription() string
+	widget(c *Context)
+	Reset()
+}
+
+type varRegistry struct {
+	vars map[string]varEntry
+
+	// pending is the overrides for the vars not registered yet.
+	pending map[string]json.RawMessage
+
+	m sync.Mutex
+}
+
+var theVarRegistry varRegistry
+
+func registerVar[T comparable](v *Var[T]) *Var[T] {
+	if v.path == "" || slices.Contains(strings.Split(v.path, "/"), "") {
+		panic(fmt.Sprintf("debugui: invalid var path: %q", v.path))
+	}
+	v.clamp()
+	v.defaultValue = v.value
+
+	r := &theVarRegistry
+	r.m.Lock()
+	defer r.m.Unlock()
+	if _, ok := r.vars[v.path]; ok {
+		panic(fmt.Sprintf("debugui: var %s is already registered", v.path))
+	}
+	if r.vars == nil {
+		r.vars = map[string]varEntry{}
+	}
+	r.vars[v.path] = v
+	if data, ok := r.pending[v.path]; ok {
+		delete(r.pending, v.path)
+		// An invalid override is ignored.
+		_ = v.unmarshalValue(data)
+	}
+	return v
+}
+
+// RegisterBoolVar registers a bool var with the given path, default value and description.
+//
+// A path consists of names separated by slashes, such as "render/shadows/enabled".
+// The names except the last one are the groups of the var.
+// RegisterBoolVar panics if the path is invalid or already registered.
+func RegisterBoolVar(path string, value bool, description string) *Var[bool] {
+	return registerVar(&Var[bool]{
+		path:        path,
+		description: description,
+		value:       value,
+	})
+}
+
+// RegisterIntVar registers an int var with the given path, default value, range and description.
+//
+// If low >= high, the range is unlimited.
+// RegisterIntVar panics if the path is invalid or already registered.
+func RegisterIntVar(path string, value int, low, high int, description string) *Var[int] {
+	return registerVar(&Var[int]{
+		path:        path,
+		description: description,
+		value:       value,
+		low:         float64(low),
+		high:        float64(high),
+	})
+}
+
+// RegisterFloatVar registers a float64 var with the given path, default value, range and description.
+//
+// If low >= high, the range is unlimited.
+// RegisterFloatVar panics if the path is invalid or already registered.
+func RegisterFloatVar(path string, value float64, low, high float64, description string) *Var[float64] {
+	return registerVar(&Var[float64]{
+		path:        path,
+		description: description,
+		value:       value,
+		low:         low,
+		high:        high,
+	})
+}
+
+// RegisterStringVar registers a string var with the given path, default value and description.
+//
+// RegisterStringVar panics if the path is invalid or already registered.
+func RegisterStringVar(path string, value string, description string) *Var[string] {
+	return registerVar(&Var[string]{
+		path:        path,
+		description: description,
+		value:       value,
+	})
+}
+
+// RegisterEnumVar registers an enum var with the given path, default index, names of the values and description.
+//
+// The value of an enum var is the index of names.
+// RegisterEnumVar panics if the path is invalid or already registered, or names is empty.
+func RegisterEnumVar(path string, value int, names []string, description string) *Var[int] {
+	if len(names) == 0 {
+		panic(fmt.Sprintf("debugui: names must not be empty for var %s", path))
+	}
+	return registerVar(&Var[int]{
+		path:        path,
+		description: description,
+		value:       value,
+		enumNames:   slices.Clone(names),
+	})
+}
+
+// RegisterColorVar registers a color var with the given path, default value and description.
+//
+// RegisterColorVar panics if the path is invalid or already registered.
+func RegisterColorVar(path string, value color.NRGBA, description string) *Var[color.NRGBA] {
+	return registerVar(&Var[color.NRGBA]{
+		path:        path,
+		description: description,
+		value:       value,
+	})
+}
+
+// sortedVars returns the registered vars sorted by the paths.
+func (r *varRegistry) sortedVars() []varEntry {
+	r.m.Lock()
+	defer r.m.Unlock()
+	vars := make([]varEntry, 0, len(r.vars))
+	for _, path := range slices.Sorted(maps.Keys(r.vars)) {
+		vars = append(vars, r.vars[path])
+	}
+	return vars
+}
+
+// VarOverrides returns the values of the vars different from the default values in JSON.
+//
+// The result is an object whose keys are the paths of the vars.
+// Enum values are encoded as the names, and colors are encoded as "#rrggbbaa".
+func VarOverrides() ([]byte, error) {
+	overrides := map[string]json.RawMessage{}
+	for _, v := range theVarRegistry.sortedVars() {
+		if !v.overridden() {
+			continue
+		}
+		data, err := v.marshalValue()
+		if err != nil {
+			return nil, err
+		}
+		overrides[v.varPath()] = data
+	}
+	return json.MarshalIndent(overrides, "", "  ")
+}
+
+// SetVarOverrides sets the values of the vars from JSON returned by [VarOverrides].
+//
+// The vars not in the data are reset to the default values.
+// The values for the vars not registered yet are applied when the vars are registered.
+func SetVarOverrides(data []byte) error {
+	var overrides map[string]json.RawMessage
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return err
+	}
+
+	r := &theVarRegistry
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.pending = nil
+	var errs []error
+	for path, v := range r.vars {
+		value, ok := overrides[path]
+		if !ok {
+			v.Reset()
+			continue
+		}
+		if err := v.unmarshalValue(value); err != nil {
+			errs = append(errs, err)
+		}
+		delete(overrides, path)
+	}
+	if len(overrides) > 0 {
+		r.pending = overrides
+	}
+	return errors.Join(errs...)
+}
+
+// ResetVars resets all the vars to the default values.
+func ResetVars() {
+	for _, v := range theVarRegistry.sortedVars() {
+		v.Reset()
+	}
+}
+
+// VarsOptions represents options for [Context.Vars].
+type VarsOptions struct {
+	// File is the name of the file to save and load the overrides of the vars.
+	//
+	// If File is empty, the Save and Load buttons are not shown.
+	File string
+}
+
+type varsState struct {
+	search string
+
+	// confirmReset reports whether the Reset button was clicked once and waits for the confirmation.
+	confirmReset bool
+
+	// status is the result of the last save or load.
+	status string
+}
+
+// Vars creates a tree of all the registered vars with widgets to edit the values.
+//
+// The vars are grouped by their paths. The text field at the top filters the vars by the paths and the descriptions.
+// The names of the vars different from the default values are shown in bold, and such vars have a button to reset them.
+// The Reset button resets all the vars after the second click, and any other click cancels it.
+//
+// options can be nil.
+//
+// A Vars widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Vars(options *VarsOptions) {
+	pc := caller()
+	idPart := idPartFromCaller(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			err = c.vars(options, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// testHookVarsReset is called right after the Reset button of Vars is laid out, if not nil.
+var testHookVarsReset func(c *Context)
+
+func (c *Context) vars(options *VarsOptions, id widgetID) error {
+	if options == nil {
+		options = &VarsOptions{}
+	}
+
+	cnt := c.container(id, 0)
+	s := widgetState[varsState](cnt, id)
+
+	widths := []int{-1, 0}
+	if options.File != "" {
+		widths = append(widths, 0, 0)
+	}
+	if err := c.setGridLayout(widths, nil); err != nil {
+		return err
+	}
+	if _, err := c.textField(&s.search, id.push(idPartFromString("search")), 0); err != nil {
+		return err
+	}
+	c.setTooltip(c.currentBounds, "Search")
+	label := "Reset"
+	if s.confirmReset {
+		label = "Confirm"
+	}
+	e, err := c.button(label, optionAlignCenter, id.push(idPartFromString("reset")))
+	if err != nil {
+		return err
+	}
+	if testHookVarsReset != nil {
+		testHookVarsReset(c)
+	}
+	if e != nil {
+		if s.confirmReset {
+			ResetVars()
+		}
+		s.confirmReset = !s.confirmReset
+	} else if c.pointing.justPressed() {
+		s.confirmReset = false
+	}
+	if options.File != "" {
+		e, err := c.button("Save", optionAlignCenter, id.push(idPartFromString("save")))
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			s.status = "Saved"
+			if err := saveVarOverrides(options.File); err != nil {
+				s.status = err.Error()
+			}
+		}
+		e, err = c.button("Load", optionAlignCenter, id.push(idPartFromString("load")))
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			s.status = "Loaded"
+			if err := loadVarOverrides(options.File); err != nil {
+				s.status = err.Error()
+			}
+		}
+	}
+	if s.status != "" {
+		if err := c.setGridLayout([]int{-1}, nil); err != nil {
+			return err
+		}
+		c.text(s.status, 0)
+	}
+
+	vars := theVarRegistry.sortedVars()
+	if search := strings.ToLower(strings.TrimSpace(s.search)); search != "" {
+		// Show the matched vars without the tree.
+		for _, v := range vars {
+			if v.matches(search) {
+				c.varRow(v, v.varPath())
+			}
+		}
+		return nil
+	}
+	c.varTree(vars, 0)
+	return nil
+}
+
+func saveVarOverrides(name string) error {
+	data, err := VarOverrides()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0644)
+}
+
+func loadVarOverrides(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return SetVarOverrides(data)
+}
+
+// varTree lays out the vars sorted by the paths as a tree.
+// depth is the number of the path names used for the groups of the current tree node.
+func (c *Context) varTree(vars []varEntry, depth int) {
+	for i := 0; i < len(vars); {
+		names := strings.Split(vars[i].varPath(), "/")
+		if len(names) == depth+1 {
+			c.varRow(vars[i], names[depth])
+			i++
+			continue
+		}
+
+		// Collect the vars in the same group.
+		group := strings.Join(names[:depth+1], "/") + "/"
+		j := i + 1
+		for j < len(vars) && strings.HasPrefix(vars[j].varPath(), group) {
+			j++
+		}
+		var opt option
+		if depth == 0 {
+			opt |= optionExpanded
+		}
+		id := c.idStack.push(idPartFromString(group))
+		if err := c.treeNode(names[depth], opt, id, func() {
+			c.varTree(vars[i:j], depth+1)
+		}); err != nil && c.err == nil {
+			c.err = err
+		}
+		i = j
+	}
+}
+
+func (c *Context) varRow(v varEntry, label string) {
+	c.idScopeFromIDPart(idPartFromString(v.varPath()), func(id widgetID) {
+		c.SetGridLayout([]int{-2, -3, c.lineHeight()}, nil)
+		_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+			c.pushClipRect(bounds)
+			defer c.popClipRect()
+			y := bounds.Min.Y + (bounds.Dy()-c.lineHeight())/2
+			c.drawStyledText(label, image.Pt(bounds.Min.X+c.style().padding, y), c.style().colors[colorText], v.overridden())
+		})
+		if desc := v.varDescription(); desc != "" {
+			c.setTooltip(c.currentBounds, v.varPath()+"\n"+desc)
+		} else {
+			c.setTooltip(c.currentBounds, v.varPath())
+		}
+		v.widget(c)
+
+		// Keep the column even if the var is not overridden so that the widgets are aligned.
+		if !v.overridden() {
+			_, _ = c.widget(widgetID{}, 0, nil, nil, nil)
+			return
+		}
+		resetID := id.push(idPartFromString("reset"))
+		e, _ := c.widget(resetID, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			var e EventHandler
+			if c.pointing.justPressed() && c.focus == resetID {
+				e = &eventHandler{}
+			}
+			return e
+		}, func(bounds image.Rectangle) {
+			c.drawWidgetFrame(resetID, bounds, colorButton, 0)
+			c.drawIcon(iconClose, bounds, c.style().colors[colorText])
+		})
+		c.setTooltip(c.currentBounds, "Reset to the default value")
+		if e != nil {
+			v.Reset()
+		}
+	})
+}
+
+func (v *Var[T]) widget(c *Context) {
+	switch p := any(&v.value).(type) {
+	case *bool:
+		c.Checkbox(p, "")
+	case *int:
+		switch {
+		case v.enumNames != nil:
+			c.Dropdown(p, v.enumNames)
+		case v.hasRange():
+			c.Slider(p, int(v.low), int(v.high), 1)
+		default:
+			c.NumberField(p, 1)
+		}
+	case *float64:
+		if v.hasRange() {
+			c.SliderF(p, v.low, v.high, (v.high-v.low)/100, 3)
+		} else {
+			c.NumberFieldF(p, 0.1, 3)
+		}
+	case *string:
+		c.TextField(p)
+	case *color.NRGBA:
+		c.GridCell(func(bounds image.Rectangle) {
+			c.SetGridLayout([]int{-1, -1, -1, -1, c.lineHeight()}, nil)
+			for i, ch := range []*uint8{&p.R, &p.G, &p.B, &p.A} {
+				c.idScopeFromIDPart(idPartFromInt(i), func(id widgetID) {
+					value := int(*ch)
+					c.Slider(&value, 0, 255, 1)
+					*ch = uint8(value)
+				})
+			}
+			_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
+				c.drawRect(bounds, *p)
+				c.drawBox(bounds, c.style().colors[colorBorder])
+			})
+		})
+	}
+}
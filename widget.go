@@ -5,6 +5,7 @@ package debugui
 
 import (
 	"image"
+	"math"
 )
 
 // widgetID is a unique identifier for a widget.
@@ -49,6 +50,10 @@ const (
 	optionPopup
 	optionClosed
 	optionExpanded
+	optionModal
+	optionTooltip
+	optionCloseButton
+	optionResizeHandle
 )
 
 func (c *Context) pointingOver(bounds image.Rectangle) bool {
@@ -59,7 +64,19 @@ func (c *Context) pointingOver(bounds image.Rectangle) bool {
 	if !p.In(c.clipRect()) {
 		return false
 	}
-	return c.hoveringRootContainer() == c.currentRootContainer()
+	// The resize handles outside a window hide the widgets behind them.
+	cnt := c.currentRootContainer()
+	return c.hoveringRootContainer() == cnt && c.resizeHandleRootContainer() == cnt
+}
+
+// pointingOverResizeHandle reports whether the pointing device is on the resize handle of the current root container.
+//
+// The handle might be outside the window, where the pointing device doesn't hover the window.
+func (c *Context) pointingOverResizeHandle(bounds image.Rectangle) bool {
+	if !c.pointingPosition().In(bounds) {
+		return false
+	}
+	return c.resizeHandleRootContainer() == c.currentRootContainer()
 }
 
 func (c *Context) pointingDelta() image.Point {
@@ -72,8 +89,8 @@ func (c *Context) pointingDelta() image.Point {
 
 func (c *Context) pointingPosition() image.Point {
 	p := c.pointing.position()
-	p.X /= c.Scale()
-	p.Y /= c.Scale()
+	p.X = int(math.Floor(float64(p.X) / c.ScaleF()))
+	p.Y = int(math.Floor(float64(p.Y) / c.ScaleF()))
 	return p
 }
 
@@ -89,7 +106,12 @@ func (c *Context) handleInputForWidget(id widgetID, bounds image.Rectangle, opt
 		return false
 	}
 
-	hover := c.pointingOver(bounds)
+	var hover bool
+	if (opt & optionResizeHandle) != 0 {
+		hover = c.pointingOverResizeHandle(bounds)
+	} else {
+		hover = c.pointingOver(bounds)
+	}
 	if hover {
 		c.hover = id
 	}
@@ -118,6 +140,7 @@ func (c *Context) handleInputForWidget(id widgetID, bounds image.Rectangle, opt
 
 func (c *Context) widget(id widgetID, opt option, layout func(bounds image.Rectangle), handleInput func(bounds image.Rectangle, wasFocused bool) EventHandler, draw func(bounds image.Rectangle)) (EventHandler, error) {
 	c.currentID = id
+	c.widgetCount++
 	bounds, err := c.layoutNext()
 	if err != nil {
 		return nil, err
@@ -141,6 +164,7 @@ func (c *Context) widget(id widgetID, opt option, layout func(bounds image.Recta
 		}()
 		layout(bounds)
 	}
+	c.currentBounds = bounds
 
 	wasFocused := c.handleInputForWidget(id, bounds, opt)
 	var e EventHandler
@@ -160,6 +184,8 @@ func (c *Context) widget(id widgetID, opt option, layout func(bounds image.Recta
 
 func (c *Context) widgetWithBounds(id widgetID, opt option, bounds image.Rectangle, handleInput func(bounds image.Rectangle, wasFocused bool) EventHandler, draw func(bounds image.Rectangle)) EventHandler {
 	c.currentID = id
+	c.currentBounds = bounds
+	c.widgetCount++
 
 	wasFocused := c.handleInputForWidget(id, bounds, opt)
 	var e EventHandler
@@ -177,10 +203,10 @@ func (c *Context) widgetWithBounds(id widgetID, opt option, bounds image.Rectang
 // A Checkbox widget is uniquely determined by its call location.
 // Function calls made in different locations will create different widgets.
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
-func (c *Context) Checkbox(state *bool, label string) EventHandler {
+func (c *Context) Checkbox(state *bool, label string) WidgetEventHandler {
 	pc := caller()
 	id := c.idStack.push(idPartFromCaller(pc))
-	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
 		return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 			var e EventHandler
 			c.handleInputForWidget(id, bounds, 0)
@@ -190,19 +216,63 @@ func (c *Context) Checkbox(state *bool, label string) EventHandler {
 			}
 			return e
 		}, func(bounds image.Rectangle) {
-			box := image.Rect(bounds.Min.X, bounds.Min.Y+(bounds.Dy()-lineHeight())/2, bounds.Min.X+lineHeight(), bounds.Max.Y-(bounds.Dy()-lineHeight())/2)
-			c.drawWidgetFrame(id, box, colorBase, 0)
+			c.drawCheckbox(id, bounds, *state, label)
+		})
+	})
+}
+
+// Switch creates an on/off switch with the given boolean state and text label.
+//
+// Switch returns an EventHandler to handle value change events.
+// A returned EventHandler is never nil.
+//
+// A Switch widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Switch(state *bool, label string) WidgetEventHandler {
+	pc := caller()
+	id := c.idStack.push(idPartFromCaller(pc))
+	return c.wrapWidgetEventHandlerAndError(func() (EventHandler, error) {
+		return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			var e EventHandler
+			if c.pointing.justPressed() && c.focus == id {
+				e = &eventHandler{}
+				*state = !*state
+			}
+			return e
+		}, func(bounds image.Rectangle) {
+			h := c.lineHeight()
+			y := bounds.Min.Y + (bounds.Dy()-h)/2
+			track := image.Rect(bounds.Min.X, y, bounds.Min.X+h*2, y+h)
+			c.drawWidgetFrame(id, track, colorBase, 0)
+			knob := image.Rect(track.Min.X, track.Min.Y, track.Min.X+h, track.Max.Y)
 			if *state {
-				c.drawIcon(iconCheck, box, c.style().colors[colorText])
+				knob = knob.Add(image.Pt(h, 0))
+				c.drawFrame(knob, colorButtonFocus)
+			} else {
+				c.drawFrame(knob, colorButton)
 			}
 			if label != "" {
-				bounds = image.Rect(bounds.Min.X+lineHeight(), bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+				bounds = image.Rect(track.Max.X, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
 				c.drawWidgetText(label, bounds, colorText, 0)
 			}
 		})
 	})
 }
 
+// drawCheckbox draws a check box with the label, which is used for a checkbox and a radio button.
+func (c *Context) drawCheckbox(id widgetID, bounds image.Rectangle, checked bool, label string) {
+	box := image.Rect(bounds.Min.X, bounds.Min.Y+(bounds.Dy()-c.lineHeight())/2, bounds.Min.X+c.lineHeight(), bounds.Max.Y-(bounds.Dy()-c.lineHeight())/2)
+	c.drawWidgetFrame(id, box, colorBase, 0)
+	if checked {
+		c.drawIcon(iconCheck, box, c.style().colors[colorText])
+	}
+	if label != "" {
+		bounds = image.Rect(bounds.Min.X+c.lineHeight(), bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		c.drawWidgetText(label, bounds, colorText, 0)
+	}
+}
+
 func (c *Context) setFocus(id widgetID) {
 	c.focus = id
 	c.keepFocus = true